@@ -0,0 +1,254 @@
+package cleanweb
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/proto"
+)
+
+// BrowserWaitStrategy controls when a browser-driven fetch considers a page
+// ready to scrape.
+type BrowserWaitStrategy int
+
+const (
+	// BrowserWaitLoad waits for the page's load event. This is the default.
+	BrowserWaitLoad BrowserWaitStrategy = iota
+	// BrowserWaitDOMContentLoaded waits only until the DOM is stable,
+	// without waiting for every subresource to finish loading.
+	BrowserWaitDOMContentLoaded
+	// BrowserWaitNetworkIdle waits until the page has had no in-flight
+	// network requests for a short quiet period, useful for content
+	// rendered client-side after the initial load.
+	BrowserWaitNetworkIdle
+)
+
+// BrowserPool maintains a bounded set of warm, reusable rod.Page instances,
+// each in its own incognito browser context, so concurrent fetches reuse
+// pages instead of each paying the cost of opening and tearing one down,
+// while the pool size still caps how many run at once.
+type BrowserPool struct {
+	browser *rod.Browser
+	pages   chan *rod.Page // idle pages; its capacity doubles as the concurrency gate
+
+	idleTimeout time.Duration
+
+	mu      sync.Mutex
+	lastUse map[*rod.Page]time.Time
+	closed  bool
+}
+
+// BrowserPoolOption configures a BrowserPool at construction time.
+type BrowserPoolOption func(*BrowserPool)
+
+// WithPoolIdleTimeout sets how long a page may sit idle in the pool before
+// it is evicted (closed and replaced) the next time it would be reused.
+// Defaults to 5 minutes.
+func WithPoolIdleTimeout(d time.Duration) BrowserPoolOption {
+	return func(pool *BrowserPool) { pool.idleTimeout = d }
+}
+
+// NewBrowserPool creates a BrowserPool of concurrency warm pages over
+// browser, each running in its own incognito context so pages don't share
+// cookies or storage with one another.
+func NewBrowserPool(browser *rod.Browser, concurrency int, opts ...BrowserPoolOption) (*BrowserPool, error) {
+	if concurrency <= 0 {
+		return nil, fmt.Errorf("cleanweb: browser pool concurrency must be positive")
+	}
+
+	pool := &BrowserPool{
+		browser:     browser,
+		pages:       make(chan *rod.Page, concurrency),
+		idleTimeout: 5 * time.Minute,
+		lastUse:     make(map[*rod.Page]time.Time),
+	}
+	for _, opt := range opts {
+		opt(pool)
+	}
+
+	for i := 0; i < concurrency; i++ {
+		page, err := newIncognitoPage(browser)
+		if err != nil {
+			pool.Close()
+			return nil, fmt.Errorf("cleanweb: failed to warm browser pool: %w", err)
+		}
+		pool.lastUse[page] = time.Now()
+		pool.pages <- page
+	}
+	return pool, nil
+}
+
+// newIncognitoPage opens a blank page in a fresh incognito browser context.
+func newIncognitoPage(browser *rod.Browser) (*rod.Page, error) {
+	incognito, err := browser.Incognito()
+	if err != nil {
+		return nil, err
+	}
+	return incognito.Page(proto.TargetCreateTarget{})
+}
+
+// acquire waits (respecting ctx) for an idle page, evicting and replacing it
+// first if it has sat idle past idleTimeout.
+func (pool *BrowserPool) acquire(ctx context.Context) (*rod.Page, error) {
+	select {
+	case page := <-pool.pages:
+		pool.mu.Lock()
+		idleSince := pool.lastUse[page]
+		pool.mu.Unlock()
+
+		if pool.idleTimeout > 0 && time.Since(idleSince) > pool.idleTimeout {
+			page.Close()
+			pool.mu.Lock()
+			delete(pool.lastUse, page)
+			pool.mu.Unlock()
+			replacement, err := newIncognitoPage(pool.browser)
+			if err != nil {
+				return nil, fmt.Errorf("cleanweb: failed to replace evicted browser page: %w", err)
+			}
+			return replacement, nil
+		}
+		return page, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// release returns page to the pool for reuse, or closes it if the pool has
+// since been closed.
+func (pool *BrowserPool) release(page *rod.Page) {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	if pool.closed {
+		page.Close()
+		return
+	}
+	pool.lastUse[page] = time.Now()
+	pool.pages <- page
+}
+
+// Close evicts and closes every page currently idle in the pool. Pages
+// checked out at the time Close is called are closed as they're released.
+func (pool *BrowserPool) Close() error {
+	pool.mu.Lock()
+	pool.closed = true
+	pool.mu.Unlock()
+
+	for {
+		select {
+		case page := <-pool.pages:
+			page.Close()
+		default:
+			return nil
+		}
+	}
+}
+
+// WithBrowserPool configures the Parser to serve browser-driven fetches
+// from pool instead of opening (and closing) a fresh page on p.browser for
+// every call.
+func (p *Parser) WithBrowserPool(pool *BrowserPool) *Parser {
+	p.browserPool = pool
+	return p
+}
+
+// WithBrowserWaitStrategy sets how a browser-driven fetch decides a page is
+// ready to scrape. It clears any predicate set earlier via
+// WithBrowserWaitUntil, so whichever of the two was called last decides.
+func (p *Parser) WithBrowserWaitStrategy(strategy BrowserWaitStrategy) *Parser {
+	p.waitStrategy = strategy
+	p.waitPredicate = nil
+	return p
+}
+
+// WithBrowserWaitUntil sets a custom readiness check, overriding whatever
+// BrowserWaitStrategy was configured; useful for pages whose content is
+// only present once an application-specific JS condition holds.
+func (p *Parser) WithBrowserWaitUntil(predicate func(*rod.Page) error) *Parser {
+	p.waitPredicate = predicate
+	return p
+}
+
+// WithBrowserScroll enables or disables auto-scrolling a page to the bottom
+// before scraping it, so lazy-loaded content has a chance to render.
+func (p *Parser) WithBrowserScroll(enabled bool) *Parser {
+	p.autoScroll = enabled
+	return p
+}
+
+// WithBrowserBlockResources configures the Chrome DevTools Protocol resource
+// types (e.g. "Image", "Font", "Stylesheet") that browser-driven fetches
+// abort via request interception, to make page loads faster and cheaper.
+func (p *Parser) WithBrowserBlockResources(resourceTypes []string) *Parser {
+	p.blockResources = resourceTypes
+	return p
+}
+
+// waitForPage waits for page to become ready per the Parser's configured
+// strategy: a custom predicate if one was registered via
+// WithBrowserWaitUntil, otherwise the configured BrowserWaitStrategy.
+func (p *Parser) waitForPage(page *rod.Page) error {
+	if p.waitPredicate != nil {
+		return p.waitPredicate(page)
+	}
+	switch p.waitStrategy {
+	case BrowserWaitDOMContentLoaded:
+		return page.WaitDOMStable(time.Second, 0)
+	case BrowserWaitNetworkIdle:
+		return page.WaitIdle(5 * time.Second)
+	default:
+		return page.WaitLoad()
+	}
+}
+
+// autoScrollPage scrolls page to the bottom in increments, pausing between
+// each, so content that only renders once it's in the viewport (infinite
+// scroll, lazy-loaded images) has a chance to load.
+func autoScrollPage(page *rod.Page) error {
+	_, err := page.Eval(`() => new Promise((resolve) => {
+		let total = 0
+		const step = 400
+		const timer = setInterval(() => {
+			window.scrollBy(0, step)
+			total += step
+			if (total >= document.body.scrollHeight) {
+				clearInterval(timer)
+				resolve()
+			}
+		}, 200)
+	})`)
+	return err
+}
+
+// applyResourceBlocking sets up request interception on page that aborts
+// any request whose resource type is in resourceTypes, returning a function
+// that tears the interception down. It is a no-op when resourceTypes is
+// empty.
+func applyResourceBlocking(page *rod.Page, resourceTypes []string) (func(), error) {
+	if len(resourceTypes) == 0 {
+		return func() {}, nil
+	}
+
+	blocked := make(map[proto.NetworkResourceType]bool, len(resourceTypes))
+	for _, t := range resourceTypes {
+		blocked[proto.NetworkResourceType(t)] = true
+	}
+
+	router := page.HijackRequests()
+	err := router.Add("*", "", func(ctx *rod.Hijack) {
+		if blocked[ctx.Request.Type()] {
+			ctx.Response.Fail(proto.NetworkErrorReasonBlockedByClient)
+			return
+		}
+		ctx.ContinueRequest(&proto.FetchContinueRequest{})
+	})
+	if err != nil {
+		return func() {}, err
+	}
+
+	go router.Run()
+	return func() { router.Stop() }, nil
+}
@@ -0,0 +1,68 @@
+package cleanweb
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-rod/rod"
+)
+
+// newTestPool builds a BrowserPool directly (bypassing NewBrowserPool, which
+// needs a live browser to warm pages) so acquire/release bookkeeping can be
+// exercised against placeholder pages that are never actually driven.
+func newTestPool(idleTimeout time.Duration, pages ...*rod.Page) *BrowserPool {
+	pool := &BrowserPool{
+		pages:       make(chan *rod.Page, len(pages)+1),
+		idleTimeout: idleTimeout,
+		lastUse:     make(map[*rod.Page]time.Time),
+	}
+	for _, page := range pages {
+		pool.lastUse[page] = time.Now()
+		pool.pages <- page
+	}
+	return pool
+}
+
+func TestBrowserPoolAcquireReturnsFreshPageUnevicted(t *testing.T) {
+	page := &rod.Page{}
+	pool := newTestPool(time.Hour, page)
+
+	got, err := pool.acquire(context.Background())
+	if err != nil {
+		t.Fatalf("acquire: %v", err)
+	}
+	if got != page {
+		t.Fatalf("expected acquire to return the only pooled page unevicted")
+	}
+}
+
+func TestBrowserPoolAcquireBlocksUntilContextDone(t *testing.T) {
+	pool := newTestPool(time.Hour) // no pages available
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	if _, err := pool.acquire(ctx); err == nil {
+		t.Fatalf("expected acquire to fail once the context is done with no page available")
+	}
+}
+
+func TestBrowserPoolReleaseReturnsPageForReuse(t *testing.T) {
+	page := &rod.Page{}
+	pool := newTestPool(time.Hour, page)
+
+	got, err := pool.acquire(context.Background())
+	if err != nil {
+		t.Fatalf("acquire: %v", err)
+	}
+	pool.release(got)
+
+	again, err := pool.acquire(context.Background())
+	if err != nil {
+		t.Fatalf("acquire after release: %v", err)
+	}
+	if again != page {
+		t.Fatalf("expected the released page to be handed back out")
+	}
+}
@@ -0,0 +1,242 @@
+package cleanweb
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-shiori/go-readability"
+	"github.com/patrickmn/go-cache"
+)
+
+// Cache interface defines the operations a Parser's cache backend must
+// support. Get/Set are the hot path used on every Parse call; Delete,
+// Purge, and Stats exist so callers can manage a backend directly (evict a
+// single URL, clear everything, or report on cache size) regardless of
+// which implementation is plugged in.
+type Cache interface {
+	// Get retrieves the value associated with the provided key.
+	Get(key string) (interface{}, bool)
+	// Set inserts a value into the cache under key. ttl == 0 means the
+	// entry should use the backend's default expiration (or never expire,
+	// for backends with no notion of one). ttl < 0 means the entry is
+	// already expired (e.g. a Cache-Control: max-age=0 response revalidated
+	// in the past): implementations must make it unavailable to a
+	// subsequent Get rather than treating a negative duration as "no TTL."
+	Set(key string, value interface{}, ttl time.Duration)
+	// Delete removes the entry stored under key, if any.
+	Delete(key string)
+	// Purge removes every entry the backend manages.
+	Purge()
+	// Stats reports basic information about the backend's current state.
+	Stats() CacheStats
+}
+
+// CacheStats reports basic information about a Cache backend's current
+// state.
+type CacheStats struct {
+	// Count is the number of entries currently stored.
+	Count int
+}
+
+// memoryCache adapts patrickmn/go-cache to the Cache interface, which is
+// the Parser's default backend.
+type memoryCache struct {
+	inner *cache.Cache
+}
+
+// newMemoryCache wraps a go-cache instance with the default expiration and
+// cleanup interval the Parser has always used.
+func newMemoryCache() *memoryCache {
+	return &memoryCache{inner: cache.New(fallbackCacheTTL, 7*24*time.Hour)}
+}
+
+// Get implements Cache.
+func (m *memoryCache) Get(key string) (interface{}, bool) {
+	return m.inner.Get(key)
+}
+
+// Set implements Cache.
+func (m *memoryCache) Set(key string, value interface{}, ttl time.Duration) {
+	if ttl < 0 {
+		// Already expired: go-cache treats a negative duration passed to
+		// Set as cache.NoExpiration, so forwarding it would make the entry
+		// live forever instead of making it unavailable. Just drop it.
+		m.inner.Delete(key)
+		return
+	}
+	if ttl == 0 {
+		m.inner.SetDefault(key, value)
+		return
+	}
+	m.inner.Set(key, value, ttl)
+}
+
+// Delete implements Cache.
+func (m *memoryCache) Delete(key string) {
+	m.inner.Delete(key)
+}
+
+// Purge implements Cache.
+func (m *memoryCache) Purge() {
+	m.inner.Flush()
+}
+
+// Stats implements Cache.
+func (m *memoryCache) Stats() CacheStats {
+	return CacheStats{Count: m.inner.ItemCount()}
+}
+
+// ParseOptions summarizes the Parser configuration that affects how an
+// article is produced for a URL, so a custom CacheKeyFunc can namespace
+// entries (e.g. per tenant) while still picking up the automatic
+// invalidation the default key scheme gets from ExtractorFingerprint and
+// MarkdownFingerprint whenever the effective pipeline changes.
+type ParseOptions struct {
+	FormatMarkdown       bool
+	ExtractorFingerprint string
+	MarkdownFingerprint  string
+}
+
+// CacheKeyFunc computes the cache key under which the article fetched from
+// uri, with the given effective ParseOptions, is stored.
+type CacheKeyFunc func(uri string, opts ParseOptions) string
+
+// WithCacheKeyFunc overrides how the Parser computes cache keys. A custom
+// fn should fold opts.ExtractorFingerprint and opts.MarkdownFingerprint
+// into its own key, or changing WithExtractors/WithMarkdownOptions won't
+// invalidate entries cached under the old pipeline.
+func (p *Parser) WithCacheKeyFunc(fn CacheKeyFunc) *Parser {
+	p.cacheKeyFunc = fn
+	return p
+}
+
+// parseOptions computes the Parser's current effective ParseOptions.
+func (p *Parser) parseOptions() ParseOptions {
+	return ParseOptions{
+		FormatMarkdown:       p.isFormatMarkdown,
+		ExtractorFingerprint: extractorFingerprint(p.extractors),
+		MarkdownFingerprint:  markdownFingerprint(p.markdownGeneration),
+	}
+}
+
+// cacheKey computes the cache key for uri, using the Parser's configured
+// CacheKeyFunc if one was set via WithCacheKeyFunc, or the default scheme
+// otherwise.
+func (p *Parser) cacheKey(uri string) string {
+	opts := p.parseOptions()
+	if p.cacheKeyFunc != nil {
+		return p.cacheKeyFunc(uri, opts)
+	}
+	return fmt.Sprintf("cleanweb:%s:%v:%s:%s", uri, opts.FormatMarkdown, opts.ExtractorFingerprint, opts.MarkdownFingerprint)
+}
+
+// extractorFingerprint identifies the configured Extractor chain by the
+// concrete type of each extractor, in order, so swapping extractors
+// invalidates previously cached entries.
+func extractorFingerprint(extractors []Extractor) string {
+	if len(extractors) == 0 {
+		return "none"
+	}
+	names := make([]string, len(extractors))
+	for i, e := range extractors {
+		names[i] = fmt.Sprintf("%T", e)
+	}
+	return shortHash(strings.Join(names, ","))
+}
+
+// markdownFingerprint identifies the configured Markdown options by
+// generation rather than by the registered functions' identity:
+// reflect.Value.Pointer() is an explicitly unreliable way to fingerprint
+// distinct option values (Go may merge functions with identical generated
+// code), which would silently undermine cache invalidation for a persistent
+// backend. generation is Parser.markdownGeneration, bumped on every
+// WithMarkdownOptions/WithHostMarkdownOptions call, so registering more
+// options on a Parser invalidates the Markdown it cached before. The count
+// is kept per Parser: two Parsers that made the same number of
+// registrations (for example in separate processes sharing a persistent
+// backend) get the same fingerprint even when their options differ.
+func markdownFingerprint(generation int) string {
+	if generation == 0 {
+		return "none"
+	}
+	return shortHash(fmt.Sprintf("%d", generation))
+}
+
+// shortHash returns a short, stable hex digest of s, used to keep
+// fingerprints out of the way in a cache key without needing to be
+// human-readable.
+func shortHash(s string) string {
+	sum := sha1.Sum([]byte(s))
+	return hex.EncodeToString(sum[:])[:12]
+}
+
+// cacheEntryJSON is the JSON-serializable mirror of cachedArticle used by
+// backends that persist outside the process (DiskCache, RedisCache) and so
+// can't round-trip cachedArticle's unexported fields directly.
+type cacheEntryJSON struct {
+	Title         string     `json:"title"`
+	Byline        string     `json:"byline"`
+	Content       string     `json:"content"`
+	TextContent   string     `json:"text_content"`
+	Length        int        `json:"length"`
+	Excerpt       string     `json:"excerpt"`
+	SiteName      string     `json:"site_name"`
+	Image         string     `json:"image"`
+	Favicon       string     `json:"favicon"`
+	PublishedTime *time.Time `json:"published_time,omitempty"`
+
+	ETag         string    `json:"etag,omitempty"`
+	LastModified string    `json:"last_modified,omitempty"`
+	FetchedAt    time.Time `json:"fetched_at"`
+	ExpiresAt    time.Time `json:"expires_at"`
+	NoStore      bool      `json:"no_store,omitempty"`
+}
+
+// toCacheEntryJSON converts a cachedArticle into its JSON-serializable form.
+func toCacheEntryJSON(c cachedArticle) cacheEntryJSON {
+	return cacheEntryJSON{
+		Title:         c.article.Title,
+		Byline:        c.article.Byline,
+		Content:       c.article.Content,
+		TextContent:   c.article.TextContent,
+		Length:        c.article.Length,
+		Excerpt:       c.article.Excerpt,
+		SiteName:      c.article.SiteName,
+		Image:         c.article.Image,
+		Favicon:       c.article.Favicon,
+		PublishedTime: c.article.PublishedTime,
+		ETag:          c.meta.etag,
+		LastModified:  c.meta.lastModified,
+		FetchedAt:     c.meta.fetchedAt,
+		ExpiresAt:     c.meta.expiresAt,
+		NoStore:       c.meta.noStore,
+	}
+}
+
+// fromCacheEntryJSON reconstructs a cachedArticle from its JSON form.
+func fromCacheEntryJSON(e cacheEntryJSON) cachedArticle {
+	return cachedArticle{
+		article: readability.Article{
+			Title:         e.Title,
+			Byline:        e.Byline,
+			Content:       e.Content,
+			TextContent:   e.TextContent,
+			Length:        e.Length,
+			Excerpt:       e.Excerpt,
+			SiteName:      e.SiteName,
+			Image:         e.Image,
+			Favicon:       e.Favicon,
+			PublishedTime: e.PublishedTime,
+		},
+		meta: cacheMeta{
+			etag:         e.ETag,
+			lastModified: e.LastModified,
+			fetchedAt:    e.FetchedAt,
+			expiresAt:    e.ExpiresAt,
+			noStore:      e.NoStore,
+		},
+	}
+}
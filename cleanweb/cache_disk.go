@@ -0,0 +1,169 @@
+package cleanweb
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// DiskCache is a filesystem-backed Cache that content-addresses each entry
+// by the sha1 of its key: a cachedArticle's body (HTML or Markdown,
+// whichever the Parser produced) is written to "<sha1>.blob", alongside a
+// "<sha1>.json" sidecar holding its metadata and expiration. Values that
+// aren't a cachedArticle are stored as raw JSON inside the sidecar itself,
+// with no blob file, for forward compatibility.
+type DiskCache struct {
+	dir string
+	mu  sync.Mutex
+}
+
+// NewDiskCache creates a DiskCache rooted at dir, creating dir (and any
+// missing parents) if it doesn't already exist.
+func NewDiskCache(dir string) (*DiskCache, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("cleanweb: failed to create disk cache dir: %w", err)
+	}
+	return &DiskCache{dir: dir}, nil
+}
+
+// diskSidecar is the JSON file written alongside (or, for non-article
+// values, instead of) a blob.
+type diskSidecar struct {
+	ExpiresAt time.Time        `json:"expires_at"`
+	Article   *cacheEntryJSON  `json:"article,omitempty"`
+	Raw       *json.RawMessage `json:"raw,omitempty"`
+}
+
+func (c *DiskCache) basePath(key string) string {
+	sum := sha1.Sum([]byte(key))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:]))
+}
+
+// Get implements Cache.
+func (c *DiskCache) Get(key string) (interface{}, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	base := c.basePath(key)
+	data, err := os.ReadFile(base + ".json")
+	if err != nil {
+		return nil, false
+	}
+	var sidecar diskSidecar
+	if err := json.Unmarshal(data, &sidecar); err != nil {
+		return nil, false
+	}
+	if !sidecar.ExpiresAt.IsZero() && time.Now().After(sidecar.ExpiresAt) {
+		os.Remove(base + ".json")
+		os.Remove(base + ".blob")
+		return nil, false
+	}
+
+	if sidecar.Article != nil {
+		blob, err := os.ReadFile(base + ".blob")
+		if err != nil {
+			return nil, false
+		}
+		entry := *sidecar.Article
+		entry.Content = string(blob)
+		return fromCacheEntryJSON(entry), true
+	}
+	if sidecar.Raw != nil {
+		var value interface{}
+		if err := json.Unmarshal(*sidecar.Raw, &value); err != nil {
+			return nil, false
+		}
+		return value, true
+	}
+	return nil, false
+}
+
+// Set implements Cache. ttl == 0 means the entry never expires; ttl < 0
+// means the entry is already expired, so it's removed rather than stored.
+func (c *DiskCache) Set(key string, value interface{}, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	base := c.basePath(key)
+	if ttl < 0 {
+		os.Remove(base + ".json")
+		os.Remove(base + ".blob")
+		return
+	}
+
+	sidecar := diskSidecar{}
+	if ttl > 0 {
+		sidecar.ExpiresAt = time.Now().Add(ttl)
+	}
+
+	if cached, ok := value.(cachedArticle); ok {
+		entry := toCacheEntryJSON(cached)
+		blob := entry.Content
+		entry.Content = "" // the body lives in the blob file, not the sidecar
+		sidecar.Article = &entry
+		if err := os.WriteFile(base+".blob", []byte(blob), 0o644); err != nil {
+			return
+		}
+	} else {
+		raw, err := json.Marshal(value)
+		if err != nil {
+			return
+		}
+		rawMsg := json.RawMessage(raw)
+		sidecar.Raw = &rawMsg
+	}
+
+	data, err := json.Marshal(sidecar)
+	if err != nil {
+		return
+	}
+	_ = os.WriteFile(base+".json", data, 0o644)
+}
+
+// Delete implements Cache.
+func (c *DiskCache) Delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	base := c.basePath(key)
+	os.Remove(base + ".json")
+	os.Remove(base + ".blob")
+}
+
+// Purge implements Cache, removing every blob and sidecar in the cache
+// directory.
+func (c *DiskCache) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entries, err := os.ReadDir(c.dir)
+	if err != nil {
+		return
+	}
+	for _, entry := range entries {
+		os.Remove(filepath.Join(c.dir, entry.Name()))
+	}
+}
+
+// Stats implements Cache, counting sidecar files in the cache directory.
+func (c *DiskCache) Stats() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entries, err := os.ReadDir(c.dir)
+	if err != nil {
+		return CacheStats{}
+	}
+	count := 0
+	for _, entry := range entries {
+		if filepath.Ext(entry.Name()) == ".json" {
+			count++
+		}
+	}
+	return CacheStats{Count: count}
+}
@@ -0,0 +1,72 @@
+package cleanweb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-shiori/go-readability"
+)
+
+func TestDiskCacheRoundTripsArticles(t *testing.T) {
+	dc, err := NewDiskCache(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewDiskCache: %v", err)
+	}
+
+	entry := cachedArticle{
+		article: readability.Article{Title: "Hello", Content: "<p>World</p>"},
+		meta:    cacheMeta{etag: `"v1"`, fetchedAt: time.Now(), expiresAt: time.Now().Add(time.Hour)},
+	}
+	dc.Set("key", entry, time.Hour)
+
+	got, ok := dc.Get("key")
+	if !ok {
+		t.Fatalf("expected the entry to round-trip")
+	}
+	restored, ok := got.(cachedArticle)
+	if !ok {
+		t.Fatalf("expected a cachedArticle, got %T", got)
+	}
+	if restored.article.Title != "Hello" || restored.article.Content != "<p>World</p>" {
+		t.Fatalf("article did not round-trip correctly: %+v", restored.article)
+	}
+	if restored.meta.etag != `"v1"` {
+		t.Fatalf("expected etag to round-trip, got %q", restored.meta.etag)
+	}
+}
+
+func TestDiskCacheExpiresEntries(t *testing.T) {
+	dc, err := NewDiskCache(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewDiskCache: %v", err)
+	}
+
+	dc.Set("key", cachedArticle{article: readability.Article{Title: "stale"}}, -time.Second)
+
+	if _, ok := dc.Get("key"); ok {
+		t.Fatalf("expected an entry set with a negative TTL to be unavailable to Get")
+	}
+}
+
+func TestDiskCacheDeleteAndPurge(t *testing.T) {
+	dc, err := NewDiskCache(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewDiskCache: %v", err)
+	}
+
+	dc.Set("a", cachedArticle{article: readability.Article{Title: "a"}}, time.Hour)
+	dc.Set("b", cachedArticle{article: readability.Article{Title: "b"}}, time.Hour)
+
+	dc.Delete("a")
+	if _, ok := dc.Get("a"); ok {
+		t.Fatalf("expected key \"a\" to be gone after Delete")
+	}
+	if stats := dc.Stats(); stats.Count != 1 {
+		t.Fatalf("expected 1 entry after deleting one of two, got %d", stats.Count)
+	}
+
+	dc.Purge()
+	if stats := dc.Stats(); stats.Count != 0 {
+		t.Fatalf("expected 0 entries after Purge, got %d", stats.Count)
+	}
+}
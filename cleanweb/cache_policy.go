@@ -0,0 +1,134 @@
+package cleanweb
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-shiori/go-readability"
+)
+
+// fallbackCacheTTL is the freshness window used when a response carries
+// neither a Cache-Control max-age/s-maxage directive nor an Expires header.
+// It matches the fixed TTL the cache used before HTTP caching semantics
+// were honored.
+const fallbackCacheTTL = 24 * time.Hour
+
+// CachePolicy controls how the Parser interprets HTTP caching semantics
+// (Cache-Control, Expires, ETag, Last-Modified) when deciding whether a
+// cached article can be served as-is, should be revalidated with a
+// conditional request, or must be refetched.
+type CachePolicy struct {
+	// MinFresh requires a cached entry to remain fresh for at least this
+	// long to be served without revalidation; shorter remaining freshness
+	// is treated as stale even though the server's Cache-Control/Expires
+	// would otherwise still allow it.
+	MinFresh time.Duration
+	// MaxStale allows serving an entry for up to this long after it has
+	// become stale, instead of revalidating immediately.
+	MaxStale time.Duration
+	// ForceRefresh ignores cached freshness entirely and always
+	// revalidates (or refetches) the article.
+	ForceRefresh bool
+}
+
+// WithCachePolicy sets the cache freshness policy for the Parser and
+// returns the Parser for method chaining.
+func (p *Parser) WithCachePolicy(policy CachePolicy) *Parser {
+	p.cachePolicy = policy
+	return p
+}
+
+// cacheControlDirectives holds the Cache-Control directives relevant to
+// freshness decisions.
+type cacheControlDirectives struct {
+	noStore   bool
+	hasMaxAge bool
+	maxAge    time.Duration
+}
+
+// parseCacheControl parses the value of a Cache-Control response header,
+// preferring s-maxage over max-age when both are present.
+func parseCacheControl(header string) cacheControlDirectives {
+	var d cacheControlDirectives
+	for _, part := range strings.Split(header, ",") {
+		name, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+		name = strings.ToLower(strings.TrimSpace(name))
+		value = strings.Trim(strings.TrimSpace(value), `"`)
+
+		switch name {
+		case "no-store":
+			d.noStore = true
+		case "max-age":
+			if seconds, err := strconv.Atoi(value); err == nil && !d.hasMaxAge {
+				d.maxAge = time.Duration(seconds) * time.Second
+				d.hasMaxAge = true
+			}
+		case "s-maxage":
+			if seconds, err := strconv.Atoi(value); err == nil {
+				d.maxAge = time.Duration(seconds) * time.Second
+				d.hasMaxAge = true
+			}
+		}
+	}
+	return d
+}
+
+// cacheMeta records the HTTP caching-related response state for a fetched
+// article so a later Parse call can decide whether to serve it as-is,
+// revalidate with a conditional GET, or refetch entirely.
+type cacheMeta struct {
+	etag         string
+	lastModified string
+	fetchedAt    time.Time
+	expiresAt    time.Time
+	noStore      bool
+}
+
+// computeCacheMeta derives a cacheMeta from an HTTP response, honoring
+// Cache-Control's max-age/s-maxage/no-store and falling back to Expires
+// (parsed via http.ParseTime) when Cache-Control is absent.
+func computeCacheMeta(resp *http.Response, now time.Time) cacheMeta {
+	meta := cacheMeta{
+		etag:         resp.Header.Get("ETag"),
+		lastModified: resp.Header.Get("Last-Modified"),
+		fetchedAt:    now,
+		expiresAt:    now.Add(fallbackCacheTTL),
+	}
+
+	if cc := resp.Header.Get("Cache-Control"); cc != "" {
+		directives := parseCacheControl(cc)
+		meta.noStore = directives.noStore
+		if directives.hasMaxAge {
+			meta.expiresAt = now.Add(directives.maxAge)
+		}
+		return meta
+	}
+
+	if expires := resp.Header.Get("Expires"); expires != "" {
+		if t, err := http.ParseTime(expires); err == nil {
+			meta.expiresAt = t
+		}
+	}
+
+	return meta
+}
+
+// stale reports whether meta has passed its freshness window under policy.
+func (meta cacheMeta) stale(policy CachePolicy, now time.Time) bool {
+	if policy.ForceRefresh {
+		return true
+	}
+	if policy.MinFresh > 0 && now.Add(policy.MinFresh).After(meta.expiresAt) {
+		return true
+	}
+	return now.After(meta.expiresAt.Add(policy.MaxStale))
+}
+
+// cachedArticle is the value stored behind a cache key: the parsed article
+// plus the HTTP caching metadata needed to revalidate it later.
+type cachedArticle struct {
+	article readability.Article
+	meta    cacheMeta
+}
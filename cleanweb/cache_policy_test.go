@@ -0,0 +1,73 @@
+package cleanweb
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestComputeCacheMetaHonorsCacheControl(t *testing.T) {
+	now := time.Now()
+	resp := &http.Response{Header: http.Header{
+		"Cache-Control": {"max-age=60"},
+		"Etag":          {`"abc"`},
+	}}
+
+	meta := computeCacheMeta(resp, now)
+	if meta.noStore {
+		t.Fatalf("expected noStore to be false")
+	}
+	if !meta.expiresAt.Equal(now.Add(60 * time.Second)) {
+		t.Fatalf("expected expiresAt to honor max-age=60, got %v", meta.expiresAt)
+	}
+	if meta.etag != `"abc"` {
+		t.Fatalf("expected etag to be captured, got %q", meta.etag)
+	}
+}
+
+func TestComputeCacheMetaNoStore(t *testing.T) {
+	resp := &http.Response{Header: http.Header{"Cache-Control": {"no-store"}}}
+	if meta := computeCacheMeta(resp, time.Now()); !meta.noStore {
+		t.Fatalf("expected no-store to be honored")
+	}
+}
+
+func TestComputeCacheMetaFallsBackToExpiresHeader(t *testing.T) {
+	now := time.Now()
+	expires := now.Add(2 * time.Hour).UTC().Truncate(time.Second)
+	resp := &http.Response{Header: http.Header{"Expires": {expires.Format(http.TimeFormat)}}}
+
+	meta := computeCacheMeta(resp, now)
+	if !meta.expiresAt.Equal(expires) {
+		t.Fatalf("expected expiresAt %v from Expires header, got %v", expires, meta.expiresAt)
+	}
+}
+
+func TestComputeCacheMetaFallsBackToDefaultTTL(t *testing.T) {
+	now := time.Now()
+	resp := &http.Response{Header: http.Header{}}
+
+	meta := computeCacheMeta(resp, now)
+	if !meta.expiresAt.Equal(now.Add(fallbackCacheTTL)) {
+		t.Fatalf("expected the fallback TTL when no caching headers are present, got %v", meta.expiresAt)
+	}
+}
+
+func TestCacheMetaStale(t *testing.T) {
+	now := time.Now()
+	meta := cacheMeta{expiresAt: now.Add(time.Hour)}
+
+	if meta.stale(CachePolicy{}, now) {
+		t.Fatalf("expected a fresh entry to not be stale")
+	}
+	if !meta.stale(CachePolicy{ForceRefresh: true}, now) {
+		t.Fatalf("expected ForceRefresh to always report stale")
+	}
+	if !meta.stale(CachePolicy{MinFresh: 2 * time.Hour}, now) {
+		t.Fatalf("expected MinFresh to reject an entry that won't stay fresh long enough")
+	}
+	past := now.Add(2 * time.Hour)
+	if meta.stale(CachePolicy{MaxStale: time.Hour}, past) {
+		t.Fatalf("expected MaxStale to tolerate an entry that's only just gone stale")
+	}
+}
@@ -0,0 +1,117 @@
+package cleanweb
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisCache is a Cache backed by Redis, suitable for sharing parsed
+// articles across multiple processes. Every key is namespaced under a
+// configurable prefix so Purge and Stats don't touch unrelated keys
+// sharing the same Redis instance.
+type RedisCache struct {
+	client *redis.Client
+	prefix string
+}
+
+// NewRedisCache creates a RedisCache using client, namespacing every key
+// under prefix (e.g. "cleanweb:").
+func NewRedisCache(client *redis.Client, prefix string) *RedisCache {
+	return &RedisCache{client: client, prefix: prefix}
+}
+
+func (c *RedisCache) key(key string) string {
+	return c.prefix + key
+}
+
+// redisEntry is the JSON envelope stored under a Redis key, mirroring
+// diskSidecar's Article/Raw discriminated union (minus ExpiresAt, since
+// Redis already tracks expiration natively via the key's TTL). Without this
+// envelope, a plain json.Unmarshal into cacheEntryJSON silently "succeeds"
+// (zero-filling) against any unrelated JSON value, turning an arbitrary
+// cached value into a bogus empty article.
+type redisEntry struct {
+	Article *cacheEntryJSON  `json:"article,omitempty"`
+	Raw     *json.RawMessage `json:"raw,omitempty"`
+}
+
+// Get implements Cache.
+func (c *RedisCache) Get(key string) (interface{}, bool) {
+	data, err := c.client.Get(context.Background(), c.key(key)).Bytes()
+	if err != nil {
+		return nil, false
+	}
+
+	var entry redisEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, false
+	}
+
+	if entry.Article != nil {
+		return fromCacheEntryJSON(*entry.Article), true
+	}
+	if entry.Raw != nil {
+		var value interface{}
+		if err := json.Unmarshal(*entry.Raw, &value); err != nil {
+			return nil, false
+		}
+		return value, true
+	}
+	return nil, false
+}
+
+// Set implements Cache. ttl == 0 means the entry never expires; ttl < 0
+// means the entry is already expired, so it's deleted rather than stored.
+func (c *RedisCache) Set(key string, value interface{}, ttl time.Duration) {
+	if ttl < 0 {
+		c.Delete(key)
+		return
+	}
+
+	entry := redisEntry{}
+	if cached, ok := value.(cachedArticle); ok {
+		article := toCacheEntryJSON(cached)
+		entry.Article = &article
+	} else {
+		raw, err := json.Marshal(value)
+		if err != nil {
+			return
+		}
+		rawMsg := json.RawMessage(raw)
+		entry.Raw = &rawMsg
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	c.client.Set(context.Background(), c.key(key), data, ttl)
+}
+
+// Delete implements Cache.
+func (c *RedisCache) Delete(key string) {
+	c.client.Del(context.Background(), c.key(key))
+}
+
+// Purge implements Cache, removing every key under c.prefix.
+func (c *RedisCache) Purge() {
+	ctx := context.Background()
+	iter := c.client.Scan(ctx, 0, c.prefix+"*", 0).Iterator()
+	for iter.Next(ctx) {
+		c.client.Del(ctx, iter.Val())
+	}
+}
+
+// Stats implements Cache, counting keys under c.prefix.
+func (c *RedisCache) Stats() CacheStats {
+	ctx := context.Background()
+	count := 0
+	iter := c.client.Scan(ctx, 0, c.prefix+"*", 0).Iterator()
+	for iter.Next(ctx) {
+		count++
+	}
+	return CacheStats{Count: count}
+}
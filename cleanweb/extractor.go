@@ -0,0 +1,106 @@
+package cleanweb
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/go-shiori/go-readability"
+)
+
+// minConfidentBodyLength is the body length, in characters, below which an
+// extractor's result is considered low-confidence and later extractors in
+// the chain (notably AMPExtractor) are given a chance to improve on it.
+const minConfidentBodyLength = 200
+
+// ExtractedArticle wraps a readability.Article with the confidence the
+// producing Extractor assigns to it, so a chain of extractors can decide
+// whether to accept, merge, or discard each one's result.
+type ExtractedArticle struct {
+	readability.Article
+	Confidence float64 // 0 (no signal) to 1 (fully confident)
+}
+
+// Extractor pulls an article out of raw HTML fetched from uri. Parser runs a
+// configured chain of Extractors in order via ExtractArticle and merges
+// their results, so an Extractor only needs to fill in what it's good at
+// (e.g. metadata only, or body only) and leave the rest zero-valued.
+type Extractor interface {
+	Extract(ctx context.Context, html string, uri *url.URL) (ExtractedArticle, error)
+}
+
+// WithExtractors sets the ordered chain of Extractors the Parser runs in
+// ExtractArticle. Extractors run in order and their results are merged
+// field-by-field; an empty chain falls back to plain go-readability
+// extraction.
+func (p *Parser) WithExtractors(extractors ...Extractor) *Parser {
+	p.extractors = extractors
+	return p
+}
+
+// ExtractArticle runs html through the Parser's configured Extractor chain
+// and returns the merged result. With no chain configured, it behaves like
+// plain go-readability extraction with a confidence of 1.
+func (p *Parser) ExtractArticle(ctx context.Context, html string, uri string) (ExtractedArticle, error) {
+	parsedURL, err := url.ParseRequestURI(uri)
+	if err != nil {
+		parsedURL = nil
+	}
+
+	if len(p.extractors) == 0 {
+		article, err := readability.FromReader(strings.NewReader(html), parsedURL)
+		if err != nil {
+			return ExtractedArticle{}, err
+		}
+		return ExtractedArticle{Article: article, Confidence: 1}, nil
+	}
+
+	var merged ExtractedArticle
+	for _, extractor := range p.extractors {
+		result, err := extractor.Extract(ctx, html, parsedURL)
+		if err != nil {
+			// A failing extractor just contributes nothing; later ones in
+			// the chain may still succeed.
+			continue
+		}
+		merged = mergeArticles(merged, result)
+	}
+	return merged, nil
+}
+
+// mergeArticles fills any metadata field left empty in dst with the
+// corresponding field from src, and adopts src's body when src is both
+// longer and at least as confident as what dst already has.
+func mergeArticles(dst, src ExtractedArticle) ExtractedArticle {
+	if dst.Title == "" {
+		dst.Title = src.Title
+	}
+	if dst.Byline == "" {
+		dst.Byline = src.Byline
+	}
+	if dst.Excerpt == "" {
+		dst.Excerpt = src.Excerpt
+	}
+	if dst.SiteName == "" {
+		dst.SiteName = src.SiteName
+	}
+	if dst.Image == "" {
+		dst.Image = src.Image
+	}
+	if dst.Favicon == "" {
+		dst.Favicon = src.Favicon
+	}
+	if dst.PublishedTime == nil {
+		dst.PublishedTime = src.PublishedTime
+	}
+
+	if src.Length > dst.Length && src.Confidence >= dst.Confidence {
+		dst.Content = src.Content
+		dst.TextContent = src.TextContent
+		dst.Length = src.Length
+	}
+	if src.Confidence > dst.Confidence {
+		dst.Confidence = src.Confidence
+	}
+	return dst
+}
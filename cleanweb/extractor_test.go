@@ -0,0 +1,46 @@
+package cleanweb
+
+import (
+	"testing"
+
+	"github.com/go-shiori/go-readability"
+)
+
+func TestMergeArticlesFillsEmptyMetadata(t *testing.T) {
+	dst := ExtractedArticle{Article: readability.Article{Title: "From readability"}, Confidence: 0.3}
+	src := ExtractedArticle{Article: readability.Article{Title: "ignored", Byline: "Jane Doe", SiteName: "Example"}, Confidence: 0.5}
+
+	merged := mergeArticles(dst, src)
+	if merged.Title != "From readability" {
+		t.Fatalf("expected dst's non-empty Title to win, got %q", merged.Title)
+	}
+	if merged.Byline != "Jane Doe" {
+		t.Fatalf("expected src to fill in the empty Byline, got %q", merged.Byline)
+	}
+	if merged.SiteName != "Example" {
+		t.Fatalf("expected src to fill in the empty SiteName, got %q", merged.SiteName)
+	}
+	if merged.Confidence != 0.5 {
+		t.Fatalf("expected the merged confidence to be the higher of the two, got %v", merged.Confidence)
+	}
+}
+
+func TestMergeArticlesAdoptsLongerConfidentBody(t *testing.T) {
+	dst := ExtractedArticle{Article: readability.Article{Content: "short", Length: 5}, Confidence: 0.3}
+	src := ExtractedArticle{Article: readability.Article{Content: "a much longer body", Length: 19}, Confidence: 0.9}
+
+	merged := mergeArticles(dst, src)
+	if merged.Content != "a much longer body" {
+		t.Fatalf("expected the longer, more confident body to win, got %q", merged.Content)
+	}
+}
+
+func TestMergeArticlesKeepsShorterBodyWhenLessConfident(t *testing.T) {
+	dst := ExtractedArticle{Article: readability.Article{Content: "trusted body", Length: 12}, Confidence: 0.9}
+	src := ExtractedArticle{Article: readability.Article{Content: "a much longer but unreliable body", Length: 34}, Confidence: 0.2}
+
+	merged := mergeArticles(dst, src)
+	if merged.Content != "trusted body" {
+		t.Fatalf("expected the higher-confidence body to be kept even though it's shorter, got %q", merged.Content)
+	}
+}
@@ -0,0 +1,199 @@
+package cleanweb
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/go-shiori/go-readability"
+)
+
+// ReadabilityExtractor runs the same go-readability extraction Parse has
+// always used. It is typically the first stage of an Extractor chain.
+type ReadabilityExtractor struct{}
+
+// Extract implements Extractor.
+func (ReadabilityExtractor) Extract(ctx context.Context, html string, uri *url.URL) (ExtractedArticle, error) {
+	article, err := readability.FromReader(strings.NewReader(html), uri)
+	if err != nil {
+		return ExtractedArticle{}, err
+	}
+	confidence := 1.0
+	if len(article.TextContent) < minConfidentBodyLength {
+		confidence = 0.3
+	}
+	return ExtractedArticle{Article: article, Confidence: confidence}, nil
+}
+
+// StructuredMetadataExtractor pulls title, author, published time, and a
+// description out of JSON-LD Article/NewsArticle blocks, OpenGraph tags,
+// and Twitter Card tags. It leaves Content empty so it never displaces a
+// body another extractor found; it exists purely to fill in metadata
+// readability's body extraction can miss or get wrong.
+type StructuredMetadataExtractor struct{}
+
+// Extract implements Extractor.
+func (StructuredMetadataExtractor) Extract(ctx context.Context, html string, uri *url.URL) (ExtractedArticle, error) {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+	if err != nil {
+		return ExtractedArticle{}, err
+	}
+
+	var article readability.Article
+
+	doc.Find(`script[type="application/ld+json"]`).EachWithBreak(func(_ int, s *goquery.Selection) bool {
+		var ld jsonLDArticle
+		if err := json.Unmarshal([]byte(s.Text()), &ld); err != nil {
+			return true // malformed block, keep looking at the rest
+		}
+		if ld.Type != "Article" && ld.Type != "NewsArticle" {
+			return true
+		}
+		article.Title = ld.Headline
+		article.Byline = ld.authorName()
+		article.Excerpt = ld.Description
+		if published, err := time.Parse(time.RFC3339, ld.DatePublished); err == nil {
+			article.PublishedTime = &published
+		}
+		return false // stop at the first matching block
+	})
+
+	if v, ok := doc.Find(`meta[property="og:title"]`).Attr("content"); ok && article.Title == "" {
+		article.Title = v
+	}
+	if v, ok := doc.Find(`meta[name="twitter:title"]`).Attr("content"); ok && article.Title == "" {
+		article.Title = v
+	}
+	if v, ok := doc.Find(`meta[property="og:description"]`).Attr("content"); ok && article.Excerpt == "" {
+		article.Excerpt = v
+	}
+	if v, ok := doc.Find(`meta[name="twitter:description"]`).Attr("content"); ok && article.Excerpt == "" {
+		article.Excerpt = v
+	}
+	if v, ok := doc.Find(`meta[property="article:published_time"]`).Attr("content"); ok && article.PublishedTime == nil {
+		if published, err := time.Parse(time.RFC3339, v); err == nil {
+			article.PublishedTime = &published
+		}
+	}
+	if v, ok := doc.Find(`meta[property="og:image"]`).Attr("content"); ok {
+		article.Image = v
+	}
+	if v, ok := doc.Find(`meta[property="og:site_name"]`).Attr("content"); ok {
+		article.SiteName = v
+	}
+
+	confidence := 0.0
+	if article.Title != "" {
+		confidence = 0.5
+	}
+	return ExtractedArticle{Article: article, Confidence: confidence}, nil
+}
+
+// jsonLDArticle is the minimal subset of schema.org Article/NewsArticle
+// JSON-LD fields StructuredMetadataExtractor understands.
+type jsonLDArticle struct {
+	Type          string      `json:"@type"`
+	Headline      string      `json:"headline"`
+	Description   string      `json:"description"`
+	DatePublished string      `json:"datePublished"`
+	Author        interface{} `json:"author"`
+}
+
+func (ld jsonLDArticle) authorName() string {
+	switch v := ld.Author.(type) {
+	case string:
+		return v
+	case map[string]interface{}:
+		if name, ok := v["name"].(string); ok {
+			return name
+		}
+	}
+	return ""
+}
+
+// AMPExtractor improves on a short or low-confidence primary extraction by
+// following the page's <link rel="amphtml"> (falling back to
+// rel="canonical") and re-running readability against that page.
+type AMPExtractor struct {
+	// Fetch retrieves the HTML for the URL discovered via amphtml/canonical.
+	// It is typically the Parser's own fetching logic.
+	Fetch func(ctx context.Context, pageURL string) (string, error)
+	// MinBodyLength is the TextContent length below which the primary
+	// page's extraction is considered worth replacing. Defaults to
+	// minConfidentBodyLength when zero.
+	MinBodyLength int
+}
+
+// Extract implements Extractor.
+func (e AMPExtractor) Extract(ctx context.Context, html string, uri *url.URL) (ExtractedArticle, error) {
+	threshold := e.MinBodyLength
+	if threshold <= 0 {
+		threshold = minConfidentBodyLength
+	}
+
+	primary, err := readability.FromReader(strings.NewReader(html), uri)
+	if err != nil {
+		return ExtractedArticle{}, err
+	}
+	if len(primary.TextContent) >= threshold || e.Fetch == nil {
+		return ExtractedArticle{Article: primary, Confidence: 1}, nil
+	}
+
+	altURL := findAlternateURL(html, uri)
+	if altURL == "" || (uri != nil && altURL == uri.String()) {
+		// A canonical link overwhelmingly just points back at the page
+		// itself; refetching it would only reproduce the same short content.
+		return ExtractedArticle{Article: primary, Confidence: 0.2}, nil
+	}
+
+	altHTML, err := e.Fetch(ctx, altURL)
+	if err != nil {
+		return ExtractedArticle{Article: primary, Confidence: 0.2}, nil
+	}
+
+	parsedAlt, err := url.ParseRequestURI(altURL)
+	if err != nil {
+		parsedAlt = uri
+	}
+	alt, err := readability.FromReader(strings.NewReader(altHTML), parsedAlt)
+	if err != nil {
+		return ExtractedArticle{Article: primary, Confidence: 0.2}, nil
+	}
+	return ExtractedArticle{Article: alt, Confidence: 0.9}, nil
+}
+
+// findAlternateURL returns the absolute URL of the page's amphtml link, or
+// its canonical link if no amphtml link is present.
+func findAlternateURL(html string, base *url.URL) string {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+	if err != nil {
+		return ""
+	}
+	for _, rel := range []string{"amphtml", "canonical"} {
+		href, ok := doc.Find(fmt.Sprintf(`link[rel="%s"]`, rel)).First().Attr("href")
+		if !ok || href == "" {
+			continue
+		}
+		if resolved := resolveURL(base, href); resolved != "" {
+			return resolved
+		}
+	}
+	return ""
+}
+
+// resolveURL resolves href against base, returning href verbatim (if it
+// parses) when base is nil.
+func resolveURL(base *url.URL, href string) string {
+	ref, err := url.Parse(href)
+	if err != nil {
+		return ""
+	}
+	if base == nil {
+		return ref.String()
+	}
+	return base.ResolveReference(ref).String()
+}
@@ -0,0 +1,51 @@
+package cleanweb
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestAMPExtractorSkipsSelfReferencingCanonical(t *testing.T) {
+	uri, _ := url.Parse("https://example.com/post")
+	html := `<html><head><link rel="canonical" href="https://example.com/post"></head><body>short</body></html>`
+
+	fetchCalled := false
+	e := AMPExtractor{Fetch: func(ctx context.Context, pageURL string) (string, error) {
+		fetchCalled = true
+		return "<html><body>should not be fetched</body></html>", nil
+	}}
+
+	result, err := e.Extract(context.Background(), html, uri)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fetchCalled {
+		t.Fatalf("expected Extract not to fetch a canonical URL that just points back at the page itself")
+	}
+	if result.Confidence >= 0.9 {
+		t.Fatalf("expected a low confidence for the unresolved short body, got %v", result.Confidence)
+	}
+}
+
+func TestAMPExtractorFollowsDistinctAmpLink(t *testing.T) {
+	uri, _ := url.Parse("https://example.com/post")
+	html := `<html><head><link rel="amphtml" href="https://example.com/amp/post"></head><body>short</body></html>`
+	longBody := "<html><body><article>" + strings.Repeat("content ", 100) + "</article></body></html>"
+
+	e := AMPExtractor{Fetch: func(ctx context.Context, pageURL string) (string, error) {
+		if pageURL != "https://example.com/amp/post" {
+			t.Fatalf("expected to fetch the amphtml URL, got %q", pageURL)
+		}
+		return longBody, nil
+	}}
+
+	result, err := e.Extract(context.Background(), html, uri)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Confidence != 0.9 {
+		t.Fatalf("expected confidence 0.9 for a successfully followed AMP page, got %v", result.Confidence)
+	}
+}
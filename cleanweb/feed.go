@@ -0,0 +1,233 @@
+package cleanweb
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/go-shiori/go-readability"
+	"github.com/mmcdole/gofeed"
+)
+
+// minInlineContentLength is the length, in characters, an RSS/Atom/JSON
+// Feed entry's inline content must reach before ParseFeed uses it directly
+// instead of fetching and parsing the entry's link through Parse.
+const minInlineContentLength = 500
+
+// defaultFeedConcurrency bounds how many entries (or, for ParseOPML, how
+// many feeds) are fetched at once, independent of whatever per-host rate
+// limit a Fetcher may also be enforcing.
+const defaultFeedConcurrency = 4
+
+// Result pairs an article with the URI it was parsed from, or the error
+// encountered resolving that URI, so a stream of many entries can surface
+// per-entry failures without aborting the whole batch.
+type Result struct {
+	URI     string
+	Article readability.Article
+	Err     error
+}
+
+// ParseFeed fetches uri, detects whether it is RSS 2.0, Atom, or JSON Feed,
+// and returns an article for every entry. See ParseFeedStream for how each
+// entry is resolved.
+//
+// It always drains ParseFeedStream's channel fully, even after the first
+// error, so a slow or stuck entry resolver is never left blocked trying to
+// send on a channel nobody is reading from anymore.
+func (p *Parser) ParseFeed(ctx context.Context, uri string) ([]readability.Article, error) {
+	var articles []readability.Article
+	var firstErr error
+	for result := range p.ParseFeedStream(ctx, uri) {
+		if result.Err != nil {
+			if firstErr == nil {
+				firstErr = fmt.Errorf("failed to parse feed entry %s: %w", result.URI, result.Err)
+			}
+			continue
+		}
+		articles = append(articles, result.Article)
+	}
+	return articles, firstErr
+}
+
+// ParseFeedStream behaves like ParseFeed but streams one Result per feed
+// entry on the returned channel as it becomes available, instead of
+// buffering the whole feed, so a caller processing thousands of items
+// doesn't need to hold them all in memory. The channel is closed once
+// every entry has been processed.
+//
+// For each entry, inline content (content:encoded, Atom content, or a JSON
+// Feed's content_html/content_text), or the entry's summary/description when
+// it has none of those, that is at least minInlineContentLength long is
+// parsed directly; shorter entries are instead fetched and parsed
+// through the normal Parse pipeline, so summary-only feeds still yield full
+// articles.
+func (p *Parser) ParseFeedStream(ctx context.Context, uri string) <-chan Result {
+	out := make(chan Result)
+
+	go func() {
+		defer close(out)
+
+		body, err := p.fetchRaw(ctx, uri)
+		if err != nil {
+			out <- Result{URI: uri, Err: fmt.Errorf("failed to fetch feed: %w", err)}
+			return
+		}
+
+		feed, err := gofeed.NewParser().ParseString(body)
+		if err != nil {
+			out <- Result{URI: uri, Err: fmt.Errorf("failed to parse feed: %w", err)}
+			return
+		}
+
+		sem := make(chan struct{}, defaultFeedConcurrency)
+		var wg sync.WaitGroup
+		for _, item := range feed.Items {
+			item := item
+			sem <- struct{}{}
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				defer func() { <-sem }()
+
+				result := p.resolveFeedItem(ctx, item)
+				select {
+				case out <- result:
+				case <-ctx.Done():
+				}
+			}()
+		}
+		wg.Wait()
+	}()
+
+	return out
+}
+
+// resolveFeedItem turns a single feed entry into a Result, using its inline
+// content when it's substantial enough or falling back to fetching its link
+// through Parse.
+func (p *Parser) resolveFeedItem(ctx context.Context, item *gofeed.Item) Result {
+	inline := item.Content
+	if inline == "" {
+		inline = item.Description
+	}
+
+	if len(inline) >= minInlineContentLength {
+		article, err := p.ParseHtml(ctx, inline, item.Link)
+		return Result{URI: item.Link, Article: article, Err: err}
+	}
+
+	if item.Link == "" {
+		return Result{Err: fmt.Errorf("entry %q has no link and no substantial inline content", item.Title)}
+	}
+	article, err := p.Parse(ctx, item.Link)
+	return Result{URI: item.Link, Article: article, Err: err}
+}
+
+// ParseOPML reads an OPML document from r and walks its
+// <outline xmlUrl="..."> entries (as emitted by common blogroll exporters),
+// fanning out to ParseFeed for each with bounded concurrency so a large
+// blogroll doesn't open unbounded simultaneous connections.
+func (p *Parser) ParseOPML(ctx context.Context, r io.Reader) ([]readability.Article, error) {
+	var doc opml
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to parse OPML: %w", err)
+	}
+
+	feedURLs := doc.feedURLs()
+	type feedResult struct {
+		articles []readability.Article
+		err      error
+	}
+
+	sem := make(chan struct{}, defaultFeedConcurrency)
+	results := make([]feedResult, len(feedURLs))
+	var wg sync.WaitGroup
+	for i, feedURL := range feedURLs {
+		i, feedURL := i, feedURL
+		sem <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			articles, err := p.ParseFeed(ctx, feedURL)
+			results[i] = feedResult{articles: articles, err: err}
+		}()
+	}
+	wg.Wait()
+
+	var all []readability.Article
+	for _, res := range results {
+		if res.err != nil {
+			continue // a single broken feed in a blogroll shouldn't fail the whole import
+		}
+		all = append(all, res.articles...)
+	}
+	return all, nil
+}
+
+// opml is the minimal subset of an OPML document's structure ParseOPML
+// needs: a nested tree of <outline> elements, some of which carry an
+// xmlUrl pointing at a feed.
+type opml struct {
+	XMLName xml.Name `xml:"opml"`
+	Body    opmlBody `xml:"body"`
+}
+
+type opmlBody struct {
+	Outlines []opmlOutline `xml:"outline"`
+}
+
+type opmlOutline struct {
+	XMLURL   string        `xml:"xmlUrl,attr"`
+	Outlines []opmlOutline `xml:"outline"`
+}
+
+// feedURLs collects every xmlUrl in the OPML document, at any nesting depth.
+func (d *opml) feedURLs() []string {
+	var urls []string
+	var walk func([]opmlOutline)
+	walk = func(outlines []opmlOutline) {
+		for _, o := range outlines {
+			if o.XMLURL != "" {
+				urls = append(urls, o.XMLURL)
+			}
+			walk(o.Outlines)
+		}
+	}
+	walk(d.Body.Outlines)
+	return urls
+}
+
+// fetchRaw fetches uri as a plain GET, honoring the Parser's configured
+// rate limit and robots.txt policy, and returns the response body. Unlike
+// Parse, it never consults or populates the article cache, since feed and
+// OPML documents aren't articles.
+func (p *Parser) fetchRaw(ctx context.Context, uri string) (string, error) {
+	parsedURL, err := url.ParseRequestURI(uri)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse url: %w", err)
+	}
+
+	if p.fetch != nil {
+		if !p.fetch.robotsAllowed(ctx, parsedURL, p.fetchRobotsTxt) {
+			return "", fmt.Errorf("cleanweb: %s: %w", uri, ErrRobotsDisallowed)
+		}
+		if err := p.fetch.wait(ctx, parsedURL.Host, time.Now().Add(p.timeout)); err != nil {
+			return "", err
+		}
+	}
+
+	body, resp, err := p.readConditional(parsedURL, condHeaders{})
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return body, nil
+}
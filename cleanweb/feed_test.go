@@ -0,0 +1,36 @@
+package cleanweb
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/mmcdole/gofeed"
+)
+
+func TestResolveFeedItemUsesSubstantialInlineContent(t *testing.T) {
+	p := NewParser()
+	body := "<p>" + strings.Repeat("word ", 120) + "</p>" // well past minInlineContentLength
+	item := &gofeed.Item{Link: "https://example.com/a", Content: body}
+
+	result := p.resolveFeedItem(context.Background(), item)
+	if result.Err != nil {
+		t.Fatalf("unexpected error: %v", result.Err)
+	}
+	if result.URI != item.Link {
+		t.Fatalf("expected URI %q, got %q", item.Link, result.URI)
+	}
+	if result.Article.TextContent == "" {
+		t.Fatalf("expected inline content to be parsed into an article")
+	}
+}
+
+func TestResolveFeedItemRejectsEmptyLinkWithoutInlineContent(t *testing.T) {
+	p := NewParser()
+	item := &gofeed.Item{Title: "short entry", Description: "too short"}
+
+	result := p.resolveFeedItem(context.Background(), item)
+	if result.Err == nil {
+		t.Fatalf("expected an error for an entry with no link and no substantial inline content")
+	}
+}
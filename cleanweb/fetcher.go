@@ -0,0 +1,163 @@
+package cleanweb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// ErrRateLimited is returned when a fetch would have had to wait longer
+// than the Parser's timeout for its per-host or global rate limit budget,
+// so callers running large URL batches can queue and retry instead of
+// blocking indefinitely.
+var ErrRateLimited = errors.New("cleanweb: rate limited")
+
+// ErrRobotsDisallowed is returned when a URL's host robots.txt disallows
+// fetching it for the Parser's configured user agent.
+var ErrRobotsDisallowed = errors.New("cleanweb: disallowed by robots.txt")
+
+// hostRateLimit is the configured token-bucket rate for a single host.
+type hostRateLimit struct {
+	every time.Duration
+	burst int
+}
+
+// Fetcher throttles outbound requests per host (and, optionally, globally)
+// so a Parser can be pointed at large URL batches without hammering origin
+// servers. It also optionally honors robots.txt and lets callers identify
+// themselves via User-Agent / From headers, mirroring the "polite client"
+// pattern used elsewhere for metadata fetching.
+type Fetcher struct {
+	mu            sync.Mutex
+	hostLimits    map[string]hostRateLimit
+	hostLimiters  map[string]*rate.Limiter
+	globalLimiter *rate.Limiter
+
+	userAgent  string
+	fromHeader string
+
+	respectRobots bool
+	robotsMu      sync.Mutex
+	robotsCache   map[string]*robotsRules
+}
+
+// newFetcher returns an empty Fetcher. Parser allocates one lazily the
+// first time a rate limit, user agent, or robots.txt policy is configured.
+func newFetcher() *Fetcher {
+	return &Fetcher{
+		hostLimits:   make(map[string]hostRateLimit),
+		hostLimiters: make(map[string]*rate.Limiter),
+		robotsCache:  make(map[string]*robotsRules),
+	}
+}
+
+// fetcher returns the Parser's Fetcher, allocating one on first use.
+func (p *Parser) fetcher() *Fetcher {
+	if p.fetch == nil {
+		p.fetch = newFetcher()
+	}
+	return p.fetch
+}
+
+// WithHostRateLimit configures a per-host token bucket for host: one token
+// is refilled each time the every interval elapses, up to burst tokens banked.
+func (p *Parser) WithHostRateLimit(host string, every time.Duration, burst int) *Parser {
+	f := p.fetcher()
+	f.mu.Lock()
+	f.hostLimits[host] = hostRateLimit{every: every, burst: burst}
+	delete(f.hostLimiters, host) // rebuild lazily with the new config
+	f.mu.Unlock()
+	return p
+}
+
+// WithGlobalRateLimit configures a token bucket shared across all hosts, in
+// addition to any per-host limits from WithHostRateLimit.
+func (p *Parser) WithGlobalRateLimit(every time.Duration, burst int) *Parser {
+	f := p.fetcher()
+	f.mu.Lock()
+	f.globalLimiter = rate.NewLimiter(rate.Every(every), burst)
+	f.mu.Unlock()
+	return p
+}
+
+// WithUserAgent sets the User-Agent header the Fetcher identifies itself
+// with, overriding defaultUserAgent.
+func (p *Parser) WithUserAgent(userAgent string) *Parser {
+	p.fetcher().userAgent = userAgent
+	return p
+}
+
+// WithFromHeader sets the From header the Fetcher sends on every request,
+// typically an operator contact address, per the "polite client" convention.
+func (p *Parser) WithFromHeader(from string) *Parser {
+	p.fetcher().fromHeader = from
+	return p
+}
+
+// WithRobotsTxt enables or disables honoring robots.txt, which is fetched
+// and cached per host the first time that host is requested.
+func (p *Parser) WithRobotsTxt(respect bool) *Parser {
+	p.fetcher().respectRobots = respect
+	return p
+}
+
+// wait blocks until host's (and, if configured, the global) rate limit
+// budget allows another request, or returns ErrRateLimited once waiting
+// would run past deadline.
+func (f *Fetcher) wait(ctx context.Context, host string, deadline time.Time) error {
+	ctx, cancel := context.WithDeadline(ctx, deadline)
+	defer cancel()
+
+	if limiter := f.hostLimiter(host); limiter != nil {
+		if err := limiter.Wait(ctx); err != nil {
+			return fmt.Errorf("%w: host %s: %v", ErrRateLimited, host, err)
+		}
+	}
+	if f.globalLimiter != nil {
+		if err := f.globalLimiter.Wait(ctx); err != nil {
+			return fmt.Errorf("%w: %v", ErrRateLimited, err)
+		}
+	}
+	return nil
+}
+
+// hostLimiter returns the token bucket configured for host, building it
+// lazily, or nil if no per-host limit was configured for it.
+func (f *Fetcher) hostLimiter(host string) *rate.Limiter {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if limiter, ok := f.hostLimiters[host]; ok {
+		return limiter
+	}
+	cfg, ok := f.hostLimits[host]
+	if !ok {
+		return nil
+	}
+	limiter := rate.NewLimiter(rate.Every(cfg.every), cfg.burst)
+	f.hostLimiters[host] = limiter
+	return limiter
+}
+
+// headers returns the identification headers the Fetcher applies to every
+// outbound request, falling back to defaultUserAgent when unset.
+func (f *Fetcher) headers() (userAgent, from string) {
+	userAgent = f.userAgent
+	if userAgent == "" {
+		userAgent = defaultUserAgent
+	}
+	return userAgent, f.fromHeader
+}
+
+// requestHeaders returns the User-Agent/From headers the Parser should send,
+// honoring its Fetcher's configuration when one has been set up.
+func (p *Parser) requestHeaders() (userAgent, from string) {
+	if p.fetch == nil {
+		return defaultUserAgent, ""
+	}
+	return p.fetch.headers()
+}
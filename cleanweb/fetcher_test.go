@@ -0,0 +1,33 @@
+package cleanweb
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestFetcherWaitRespectsHostRateLimit(t *testing.T) {
+	p := NewParser().WithHostRateLimit("example.com", time.Hour, 1)
+
+	// The first request consumes the lone burst token and should proceed
+	// immediately.
+	if err := p.fetch.wait(context.Background(), "example.com", time.Now().Add(time.Second)); err != nil {
+		t.Fatalf("expected the first request to proceed immediately, got %v", err)
+	}
+
+	// The second request has no tokens left and a refill of an hour, so it
+	// must fail once waiting would run past the deadline.
+	err := p.fetch.wait(context.Background(), "example.com", time.Now().Add(50*time.Millisecond))
+	if !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("expected ErrRateLimited once the deadline is exceeded, got %v", err)
+	}
+}
+
+func TestFetcherWaitIgnoresUnconfiguredHosts(t *testing.T) {
+	p := NewParser().WithHostRateLimit("example.com", time.Hour, 1)
+
+	if err := p.fetch.wait(context.Background(), "other.com", time.Now().Add(time.Second)); err != nil {
+		t.Fatalf("expected a host with no configured limit to proceed unthrottled, got %v", err)
+	}
+}
@@ -0,0 +1,54 @@
+package cleanweb
+
+import (
+	"net/url"
+
+	md "github.com/JohannesKaufmann/html-to-markdown"
+)
+
+// MarkdownOption configures the html-to-markdown Converter used when the
+// Parser formats an article as Markdown. It is typically a plugin
+// registration (converter.Use(...)) or a custom conversion rule
+// (converter.AddRules(...)).
+type MarkdownOption func(*md.Converter)
+
+// WithMarkdownOptions registers options applied to the Markdown converter
+// for every article, regardless of host. Options run in registration order,
+// before any host-specific options registered via WithHostMarkdownOptions.
+func (p *Parser) WithMarkdownOptions(opts ...MarkdownOption) *Parser {
+	p.markdownOptions = append(p.markdownOptions, opts...)
+	p.markdownGeneration++
+	return p
+}
+
+// WithHostMarkdownOptions registers options applied to the Markdown
+// converter only when converting an article fetched from host (matched
+// against parsedURL.Host), letting callers apply site-specific cleanup
+// (e.g. stripping Substack's share widgets) without affecting other sites.
+func (p *Parser) WithHostMarkdownOptions(host string, opts ...MarkdownOption) *Parser {
+	if p.hostMarkdownOptions == nil {
+		p.hostMarkdownOptions = make(map[string][]MarkdownOption)
+	}
+	p.hostMarkdownOptions[host] = append(p.hostMarkdownOptions[host], opts...)
+	p.markdownGeneration++
+	return p
+}
+
+// newMarkdownConverter builds the Converter used to render uri's article as
+// Markdown, applying the Parser's global options followed by any options
+// registered for uri's host.
+func (p *Parser) newMarkdownConverter(uri string) *md.Converter {
+	converter := md.NewConverter("", true, nil)
+
+	for _, opt := range p.markdownOptions {
+		opt(converter)
+	}
+
+	if parsedURL, err := url.ParseRequestURI(uri); err == nil {
+		for _, opt := range p.hostMarkdownOptions[parsedURL.Host] {
+			opt(converter)
+		}
+	}
+
+	return converter
+}
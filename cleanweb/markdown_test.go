@@ -0,0 +1,59 @@
+package cleanweb
+
+import (
+	"testing"
+
+	md "github.com/JohannesKaufmann/html-to-markdown"
+)
+
+func TestNewMarkdownConverterAppliesGlobalThenHostOptions(t *testing.T) {
+	var order []string
+	record := func(name string) MarkdownOption {
+		return func(*md.Converter) { order = append(order, name) }
+	}
+
+	p := NewParser().
+		WithMarkdownOptions(record("global")).
+		WithHostMarkdownOptions("example.com", record("example.com")).
+		WithHostMarkdownOptions("other.com", record("other.com"))
+
+	p.newMarkdownConverter("https://example.com/article")
+
+	if got := order; len(got) != 2 || got[0] != "global" || got[1] != "example.com" {
+		t.Fatalf("expected [global example.com], got %v", got)
+	}
+}
+
+func TestMarkdownFingerprintChangesPerRegistration(t *testing.T) {
+	noop := func(*md.Converter) {}
+
+	p := NewParser()
+	before := markdownFingerprint(p.markdownGeneration)
+
+	p.WithMarkdownOptions(noop)
+	afterGlobal := markdownFingerprint(p.markdownGeneration)
+	if afterGlobal == before {
+		t.Fatalf("expected the fingerprint to change after WithMarkdownOptions")
+	}
+
+	p.WithHostMarkdownOptions("example.com", noop)
+	afterHost := markdownFingerprint(p.markdownGeneration)
+	if afterHost == afterGlobal {
+		t.Fatalf("expected the fingerprint to change after WithHostMarkdownOptions")
+	}
+}
+
+func TestNewMarkdownConverterSkipsOtherHostsOptions(t *testing.T) {
+	var order []string
+	record := func(name string) MarkdownOption {
+		return func(*md.Converter) { order = append(order, name) }
+	}
+
+	p := NewParser().WithHostMarkdownOptions("example.com", record("example.com"))
+
+	p.newMarkdownConverter("https://other.com/article")
+
+	if len(order) != 0 {
+		t.Fatalf("expected no host options to run for a different host, got %v", order)
+	}
+}
@@ -7,13 +7,11 @@ import (
 	"io"
 	"net/http"
 	"net/url"
-	"strings"
 	"time"
 
-	md "github.com/JohannesKaufmann/html-to-markdown"
 	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/proto"
 	"github.com/go-shiori/go-readability"
-	"github.com/patrickmn/go-cache"
 	utls "github.com/refraction-networking/utls"
 	"github.com/vaayne/gtk/session"
 )
@@ -21,21 +19,25 @@ import (
 // defaultUserAgent is the user agent string used for HTTP requests.
 const defaultUserAgent = "Mozilla/5.0 (Windows NT 10.0; WOW64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/86.0.4240.198 Safari/537.36"
 
-// Cache interface defines methods for getting and setting values with a default expiration time.
-type Cache interface {
-	// Get retrieves the value associated with the provided key.
-	Get(key string) (interface{}, bool)
-	// SetDefault inserts a value into the cache using the provided key, with a default expiration time.
-	SetDefault(key string, value interface{})
-}
-
 // Parser is a struct that holds the session, browser, timeout, format, and cache client for parsing web content.
 type Parser struct {
-	sess             *session.Session // The current session
-	browser          *rod.Browser     // The browser instance used for web scraping
-	timeout          time.Duration    // The maximum time allowed for the parsing operation
-	isFormatMarkdown bool             // Flag indicating if the output should be formatted as Markdown
-	cacheClient      Cache            // The cache client used for storing and retrieving parsed content
+	sess                *session.Session            // The current session
+	browser             *rod.Browser                // The browser instance used for web scraping
+	timeout             time.Duration               // The maximum time allowed for the parsing operation
+	isFormatMarkdown    bool                        // Flag indicating if the output should be formatted as Markdown
+	cacheClient         Cache                       // The cache client used for storing and retrieving parsed content
+	cachePolicy         CachePolicy                 // Governs how cached articles are treated as fresh, stale, or revalidated
+	extractors          []Extractor                 // Ordered chain of Extractors run by ExtractArticle
+	markdownOptions     []MarkdownOption            // Options applied to every article's Markdown converter
+	hostMarkdownOptions map[string][]MarkdownOption // Options applied only when converting articles from a given host
+	markdownGeneration  int                         // Bumped on every WithMarkdownOptions/WithHostMarkdownOptions call; backs MarkdownFingerprint
+	fetch               *Fetcher                    // Per-host/global rate limiting and robots.txt policy; nil means unthrottled
+	browserPool         *BrowserPool                // Pool of warm pages for browser-driven fetches; nil means open/close a page per call
+	waitStrategy        BrowserWaitStrategy          // How a browser-driven fetch decides a page is ready to scrape
+	waitPredicate       func(*rod.Page) error        // Custom readiness check, overriding waitStrategy when set
+	autoScroll          bool                         // Whether to auto-scroll a page to the bottom before scraping it
+	blockResources      []string                     // CDP resource types (e.g. "Image") to abort during browser-driven fetches
+	cacheKeyFunc        CacheKeyFunc                 // Overrides how cache keys are computed; nil means the default scheme
 }
 
 // NewParser creates a new Parser with a default session, timeout, and cache client.
@@ -43,7 +45,7 @@ func NewParser() *Parser {
 	return &Parser{
 		sess:        session.New(session.WithClientHelloID(utls.HelloChrome_100_PSK)), // Create a new session with a Chrome User-Agent
 		timeout:     60 * time.Second,                                                 // Set a default timeout of 60 seconds
-		cacheClient: cache.New(24*time.Hour, 7*24*time.Hour),                          // Initialize a new cache client with a default expiration time of 24 hours and a cleanup interval of 7 days
+		cacheClient: newMemoryCache(),                                                 // Initialize the default in-memory cache client
 	}
 }
 
@@ -60,11 +62,24 @@ func (p *Parser) WithBrowser(browser *rod.Browser) *Parser {
 }
 
 // WithBrowserControlURL sets the browser for the Parser using a control URL and returns the Parser.
+// It panics if the browser is unreachable; use ConnectBrowser for an error-returning variant.
 func (p *Parser) WithBrowserControlURL(browserURL string) *Parser {
 	p.browser = rod.New().ControlURL(browserURL).MustConnect() // Connect to the browser using the control URL
 	return p                                                   // Return the Parser for method chaining
 }
 
+// ConnectBrowser connects to the browser at browserURL and sets it as the
+// Parser's browser, returning an error instead of panicking if the browser
+// is unreachable.
+func (p *Parser) ConnectBrowser(browserURL string) (*Parser, error) {
+	browser := rod.New().ControlURL(browserURL)
+	if err := browser.Connect(); err != nil {
+		return p, fmt.Errorf("cleanweb: failed to connect to browser: %w", err)
+	}
+	p.browser = browser
+	return p, nil
+}
+
 // WithTimeout sets the timeout for the Parser and returns the Parser.
 func (p *Parser) WithTimeout(timeout time.Duration) *Parser {
 	p.timeout = timeout // Set the timeout
@@ -77,12 +92,15 @@ func (p *Parser) WithFormatMarkdown() *Parser {
 	return p                  // Return the Parser for method chaining
 }
 
-func getCachekey(uri string, isFormatMarkdown bool) string {
-	return fmt.Sprintf("cleanweb:%s:%v", uri, isFormatMarkdown)
-}
-
 // Parse is a method of the Parser struct that takes in a context and a URI string.
 // It parses the content at the given URL and returns a readability.Article and an error.
+//
+// When a cached entry exists, Parse honors the HTTP caching semantics captured for
+// it: a still-fresh entry (per p.cachePolicy) is returned directly, a stale entry
+// is revalidated with a conditional GET (If-None-Match / If-Modified-Since), and a
+// 304 Not Modified response simply bumps the entry's timestamp and returns the
+// cached article. Browser-driven fetches have no response headers to revalidate
+// against, so they fall back to the default freshness window.
 func (p *Parser) Parse(ctx context.Context, uri string) (readability.Article, error) {
 	// Initialize variables
 	var article readability.Article
@@ -96,13 +114,29 @@ func (p *Parser) Parse(ctx context.Context, uri string) (readability.Article, er
 		return article, fmt.Errorf("failed to parse url: %w", err)
 	}
 
-	// If the cache client is initialized
+	cacheKey := p.cacheKey(uri)
+	now := time.Now()
+
+	// If the cache client is initialized, try to reuse (or revalidate) what it holds.
+	var cached cachedArticle
+	var haveCached bool
 	if p.cacheClient != nil {
-		// Try to get the article from the cache using the URI as the key
-		article, ok := p.cacheClient.Get(getCachekey(uri, p.isFormatMarkdown))
-		// If the article is in the cache, return the article and nil as the error
-		if ok {
-			return article.(readability.Article), nil
+		if v, ok := p.cacheClient.Get(cacheKey); ok {
+			cached, haveCached = v.(cachedArticle)
+			if haveCached && !cached.meta.stale(p.cachePolicy, now) {
+				return cached.article, nil
+			}
+		}
+	}
+
+	// If a Fetcher is configured, honor robots.txt and the rate limit budget
+	// before issuing any request for this host.
+	if p.fetch != nil {
+		if !p.fetch.robotsAllowed(ctx, parsedURL, p.fetchRobotsTxt) {
+			return article, fmt.Errorf("cleanweb: %s: %w", uri, ErrRobotsDisallowed)
+		}
+		if err := p.fetch.wait(ctx, parsedURL.Host, now.Add(p.timeout)); err != nil {
+			return article, err
 		}
 	}
 
@@ -110,39 +144,86 @@ func (p *Parser) Parse(ctx context.Context, uri string) (readability.Article, er
 	if p.browser != nil {
 		// Read the content at the URI using the browser
 		html, err = p.readWithBrowser(uri)
-	} else {
-		// Read the content at the URI using a GET request
-		html, err = p.read(parsedURL)
+		if err != nil {
+			return article, fmt.Errorf("failed to read url: %w", err)
+		}
+		return p.parseHTML(ctx, html, uri, cacheMeta{fetchedAt: now, expiresAt: now.Add(fallbackCacheTTL)})
 	}
+
+	// Read the content at the URI using a GET request, revalidating the stale
+	// cache entry (if any) with a conditional request.
+	var cond condHeaders
+	if haveCached {
+		cond = condHeaders{etag: cached.meta.etag, lastModified: cached.meta.lastModified}
+	}
+	html, resp, err := p.readConditional(parsedURL, cond)
 	// If there's an error reading the content, return the error
 	if err != nil {
 		return article, fmt.Errorf("failed to read url: %w", err)
 	}
 
+	if resp.StatusCode == http.StatusNotModified {
+		// The origin confirmed the cached copy is still valid: recompute its
+		// freshness window from this response and serve it without re-parsing.
+		if !haveCached {
+			return article, fmt.Errorf("received 304 Not Modified with no cached entry for %s", uri)
+		}
+		refreshed := computeCacheMeta(resp, now)
+		// A 304 commonly carries no Cache-Control/Expires of its own; in that
+		// case extend the entry by the freshness window it originally had
+		// rather than falling back to fallbackCacheTTL.
+		if resp.Header.Get("Cache-Control") == "" && resp.Header.Get("Expires") == "" {
+			if window := cached.meta.expiresAt.Sub(cached.meta.fetchedAt); window > 0 {
+				refreshed.expiresAt = now.Add(window)
+			}
+		}
+		if refreshed.etag == "" {
+			refreshed.etag = cached.meta.etag
+		}
+		if refreshed.lastModified == "" {
+			refreshed.lastModified = cached.meta.lastModified
+		}
+		cached.meta = refreshed
+		if p.cacheClient != nil {
+			p.cacheClient.Set(cacheKey, cached, time.Until(cached.meta.expiresAt))
+		}
+		return cached.article, nil
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return article, fmt.Errorf("failed to read url: unexpected status %s", resp.Status)
+	}
+
 	// Parse the HTML content and return the article and any error
-	return p.ParseHtml(ctx, html, uri)
+	return p.parseHTML(ctx, html, uri, computeCacheMeta(resp, now))
 }
 
 // ParseHtml is a method of the Parser struct that takes in a context, an HTML string, and a URI string.
-// It parses the HTML content and returns a readability.Article and an error.
+// It parses the HTML content and returns a readability.Article and an error. Direct callers (such as
+// ParseFeedStream, resolving a feed entry's inline content) get the default freshness window; Parse
+// itself goes through parseHTML directly so it can supply the real HTTP caching metadata.
 func (p *Parser) ParseHtml(ctx context.Context, html string, uri string) (readability.Article, error) {
-	// Parse the URI
-	parsedURL, err := url.ParseRequestURI(uri)
-	// If there's an error parsing the URI, set parsedURL to nil
-	if err != nil {
-		parsedURL = nil
-	}
-	// Use the readability package's FromReader function to parse the HTML content
-	article, err := readability.FromReader(strings.NewReader(html), parsedURL)
-	// If there's an error parsing the HTML content, return the error
+	now := time.Now()
+	return p.parseHTML(ctx, html, uri, cacheMeta{fetchedAt: now, expiresAt: now.Add(fallbackCacheTTL)})
+}
+
+// parseHTML runs html through the Parser's configured Extractor chain (via
+// ExtractArticle), converts to Markdown if requested, and caches the result
+// under meta, honoring meta.noStore. It is the single place that decides
+// whether a parsed article gets cached, so callers never have to worry about
+// a second, meta-unaware write clobbering their caching decision.
+func (p *Parser) parseHTML(ctx context.Context, html string, uri string, meta cacheMeta) (readability.Article, error) {
+	// Run the Parser's configured Extractor chain (or plain go-readability
+	// extraction, if none is configured)
+	extracted, err := p.ExtractArticle(ctx, html, uri)
 	if err != nil {
-		return article, fmt.Errorf("failed to parse %s, %v\n", uri, err)
+		return extracted.Article, fmt.Errorf("failed to parse %s, %v\n", uri, err)
 	}
+	article := extracted.Article
 
 	// If the Parser is set to format as Markdown
 	if p.isFormatMarkdown {
-		// Create a new Converter
-		converter := md.NewConverter("", true, nil)
+		// Create a new Converter, applying any global and per-host options
+		converter := p.newMarkdownConverter(uri)
 		// Convert the article content to Markdown
 		markdown, err := converter.ConvertString(article.Content)
 		// If there's an error converting the content to Markdown, return the error
@@ -154,57 +235,158 @@ func (p *Parser) ParseHtml(ctx context.Context, html string, uri string) (readab
 	}
 	// Set the article's Node to nil
 	article.Node = nil
-	// Add the article to the cache with the URI as the key
-	p.cacheClient.SetDefault(getCachekey(uri, p.isFormatMarkdown), article)
+	// Add the article to the cache under the key cacheKey computes for the
+	// URI, unless the response that produced it was marked no-store
+	if p.cacheClient != nil && !meta.noStore {
+		p.cacheClient.Set(p.cacheKey(uri), cachedArticle{article: article, meta: meta}, time.Until(meta.expiresAt))
+	}
 	// Return the article and nil as the error
 	return article, nil
 }
 
-// read is a method of the Parser struct that takes in a URL.
-// It sends a GET request to the given URL and returns the response body as a string and an error.
-func (p *Parser) read(u *url.URL) (string, error) {
+// condHeaders carries the validators used to make a conditional GET request.
+type condHeaders struct {
+	etag         string // sent as If-None-Match when non-empty
+	lastModified string // sent as If-Modified-Since when non-empty
+}
+
+// readConditional is a method of the Parser struct that takes in a URL and
+// the cache validators (if any) known for it. It sends a GET request,
+// attaching If-None-Match / If-Modified-Since when cond carries them, and
+// returns the response body as a string alongside the *http.Response so the
+// caller can inspect the status code and caching headers (ETag,
+// Last-Modified, Cache-Control, Expires). The response body is fully read
+// and closed before returning.
+func (p *Parser) readConditional(u *url.URL, cond condHeaders) (string, *http.Response, error) {
 	// Convert the URL to a string
 	uri := u.String()
 
 	// Set the timeout for the session client
 	p.sess.Client.Timeout = p.timeout
 	// Create a new GET request
-	req, _ := http.NewRequest("GET", uri, nil)
-	// Set the User-Agent header for the request
-	req.Header.Set("User-Agent", defaultUserAgent)
-	// Send the GET request
-	resp, err := p.sess.Get(uri)
+	req, err := http.NewRequest("GET", uri, nil)
+	if err != nil {
+		return "", nil, err
+	}
+	// Set the identification headers for the request
+	userAgent, from := p.requestHeaders()
+	req.Header.Set("User-Agent", userAgent)
+	if from != "" {
+		req.Header.Set("From", from)
+	}
+	// Attach conditional validators, if we have a cached entry to revalidate
+	if cond.etag != "" {
+		req.Header.Set("If-None-Match", cond.etag)
+	}
+	if cond.lastModified != "" {
+		req.Header.Set("If-Modified-Since", cond.lastModified)
+	}
+	// Send the request
+	resp, err := p.sess.Client.Do(req)
 	// If there's an error sending the request, return the error
 	if err != nil {
-		return "", err
+		return "", nil, err
 	}
 	// Ensure the response body is closed after the function returns
 	defer resp.Body.Close()
 	// Read all content from the response body
 	content, err := io.ReadAll(resp.Body)
 	// If there's an error reading the content, return the error
+	if err != nil {
+		return "", nil, err
+	}
+	// Return the content, the response (for status/headers), and any error
+	return string(content), resp, nil
+}
+
+// fetchRobotsTxt fetches the body of the robots.txt file at robotsURL,
+// erroring on transport failures or a non-2xx status. It is passed to
+// Fetcher.robotsAllowed so robots.txt lookups go through the Parser's own
+// session rather than a bare http.Get.
+func (p *Parser) fetchRobotsTxt(ctx context.Context, robotsURL string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", robotsURL, nil)
+	if err != nil {
+		return "", err
+	}
+	userAgent, _ := p.requestHeaders()
+	req.Header.Set("User-Agent", userAgent)
+
+	resp, err := p.sess.Client.Do(req)
 	if err != nil {
 		return "", err
 	}
-	// Return the content as a string and any error
-	return string(content), err
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", fmt.Errorf("robots.txt request returned status %s", resp.Status)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	return string(body), nil
 }
 
 // readWithBrowser is a method of the Parser struct that takes in a URI string.
 // It reads the content at the given URL using a browser and returns the HTML content as a string and an error.
+// When a BrowserPool has been configured via WithBrowserPool, it acquires and
+// releases a warm page from the pool instead of opening a fresh one on p.browser.
 func (p *Parser) readWithBrowser(uri string) (string, error) {
+	if p.browserPool != nil {
+		return p.readWithPooledPage(uri)
+	}
+
 	// If the browser is not initialized, return an error
 	if p.browser == nil {
 		return "", fmt.Errorf("browser is not initialized")
 	}
 
-	// Open a new page in the browser with the given URI
-	page := p.browser.MustPage(uri).Timeout(p.timeout)
+	// Open a new page in the browser
+	page, err := p.browser.Page(proto.TargetCreateTarget{})
+	if err != nil {
+		return "", fmt.Errorf("failed to open browser page: %w", err)
+	}
 	// Ensure the page is closed after the function returns
-	defer page.MustClose()
+	defer page.Close()
+
+	return p.fetchWithPage(page.Timeout(p.timeout), uri)
+}
+
+// readWithPooledPage behaves like readWithBrowser but acquires and releases
+// a page from p.browserPool instead of opening a new one.
+func (p *Parser) readWithPooledPage(uri string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), p.timeout)
+	defer cancel()
+
+	page, err := p.browserPool.acquire(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to acquire browser page: %w", err)
+	}
+	defer p.browserPool.release(page)
+
+	return p.fetchWithPage(page.Timeout(p.timeout), uri)
+}
+
+// fetchWithPage navigates page to uri under the Parser's configured resource
+// blocking, then waits for it to become ready (per waitForPage), optionally
+// auto-scrolls it, and returns its rendered HTML.
+func (p *Parser) fetchWithPage(page *rod.Page, uri string) (string, error) {
+	stopBlocking, err := applyResourceBlocking(page, p.blockResources)
+	if err != nil {
+		return "", fmt.Errorf("failed to configure resource blocking: %w", err)
+	}
+	defer stopBlocking()
+
+	if err := page.Navigate(uri); err != nil {
+		return "", fmt.Errorf("failed to navigate to %s: %w", uri, err)
+	}
+	if err := p.waitForPage(page); err != nil {
+		return "", fmt.Errorf("failed waiting for page to load: %w", err)
+	}
+	if p.autoScroll {
+		if err := autoScrollPage(page); err != nil {
+			return "", fmt.Errorf("failed to auto-scroll page: %w", err)
+		}
+	}
 
-	// Wait for the page to load
-	page.MustWaitLoad()
-	// Return the HTML content of the page
 	return page.HTML()
 }
@@ -0,0 +1,140 @@
+package cleanweb
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// robotsRules is the subset of a robots.txt file this package understands:
+// the Disallow/Allow rules from the group that applies to our user agent,
+// falling back to the wildcard "*" group.
+type robotsRules struct {
+	disallow []string
+	allow    []string
+}
+
+// allows reports whether path may be fetched under these rules, using the
+// longest-matching-prefix rule with Allow breaking ties, per the de facto
+// robots.txt convention. A nil robotsRules (robots.txt unreachable) allows
+// everything.
+func (r *robotsRules) allows(path string) bool {
+	if r == nil {
+		return true
+	}
+	bestAllow, bestDisallow := -1, -1
+	for _, prefix := range r.allow {
+		if strings.HasPrefix(path, prefix) && len(prefix) > bestAllow {
+			bestAllow = len(prefix)
+		}
+	}
+	for _, prefix := range r.disallow {
+		if strings.HasPrefix(path, prefix) && len(prefix) > bestDisallow {
+			bestDisallow = len(prefix)
+		}
+	}
+	return bestDisallow <= bestAllow
+}
+
+// robotsGroup is one User-agent block of a robots.txt file: the (possibly
+// several) user agents it names, as written, and the Disallow/Allow rules
+// that follow until the next block starts.
+type robotsGroup struct {
+	userAgents []string
+	rules      robotsRules
+}
+
+// parseRobotsTxt parses the body of a robots.txt file into its groups, then
+// selects the single group that most specifically matches userAgent: an
+// exact (case-insensitive) user-agent match if one exists, otherwise the
+// wildcard "*" group. Directives from any other group are discarded, since
+// robots.txt semantics pick one applicable group, not their union.
+//
+// Consecutive "User-agent:" lines belong to the same group (a common way to
+// target several bots with one rule set), and a group ends as soon as a
+// "User-agent:" line follows rules that already belong to it.
+func parseRobotsTxt(body, userAgent string) *robotsRules {
+	var groups []robotsGroup
+	startingNewGroup := true
+
+	scanner := bufio.NewScanner(strings.NewReader(body))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		field, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		field = strings.ToLower(strings.TrimSpace(field))
+		value = strings.TrimSpace(value)
+
+		switch field {
+		case "user-agent":
+			if startingNewGroup {
+				groups = append(groups, robotsGroup{})
+			}
+			group := &groups[len(groups)-1]
+			group.userAgents = append(group.userAgents, value)
+			startingNewGroup = false
+		case "disallow":
+			if len(groups) == 0 || value == "" {
+				continue
+			}
+			groups[len(groups)-1].rules.disallow = append(groups[len(groups)-1].rules.disallow, value)
+			startingNewGroup = true
+		case "allow":
+			if len(groups) == 0 || value == "" {
+				continue
+			}
+			groups[len(groups)-1].rules.allow = append(groups[len(groups)-1].rules.allow, value)
+			startingNewGroup = true
+		}
+	}
+
+	var wildcard *robotsRules
+	for i, group := range groups {
+		for _, ua := range group.userAgents {
+			if strings.EqualFold(ua, userAgent) {
+				return &groups[i].rules
+			}
+			if ua == "*" && wildcard == nil {
+				wildcard = &groups[i].rules
+			}
+		}
+	}
+	if wildcard != nil {
+		return wildcard
+	}
+	return &robotsRules{}
+}
+
+// robotsAllowed fetches (and caches) robots.txt for parsedURL's host via
+// fetch and reports whether parsedURL's path may be requested. A fetch
+// failure fails open, matching the behavior of most crawlers when
+// robots.txt is unreachable.
+func (f *Fetcher) robotsAllowed(ctx context.Context, parsedURL *url.URL, fetch func(context.Context, string) (string, error)) bool {
+	if !f.respectRobots {
+		return true
+	}
+
+	f.robotsMu.Lock()
+	rules, cached := f.robotsCache[parsedURL.Host]
+	f.robotsMu.Unlock()
+
+	if !cached {
+		robotsURL := fmt.Sprintf("%s://%s/robots.txt", parsedURL.Scheme, parsedURL.Host)
+		if body, err := fetch(ctx, robotsURL); err == nil {
+			userAgent, _ := f.headers()
+			rules = parseRobotsTxt(body, userAgent)
+		}
+		f.robotsMu.Lock()
+		f.robotsCache[parsedURL.Host] = rules
+		f.robotsMu.Unlock()
+	}
+
+	return rules.allows(parsedURL.Path)
+}
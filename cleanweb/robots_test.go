@@ -0,0 +1,48 @@
+package cleanweb
+
+import "testing"
+
+func TestParseRobotsTxtSelectsMostSpecificGroup(t *testing.T) {
+	body := `
+User-agent: *
+Disallow: /private
+Allow: /private/public
+
+User-agent: GoogleBot
+User-agent: BingBot
+Disallow: /googlebot-only
+`
+
+	t.Run("wildcard group for an unmatched agent", func(t *testing.T) {
+		rules := parseRobotsTxt(body, "MyCrawler")
+		if rules.allows("/private/secret") {
+			t.Fatalf("expected /private/secret to be disallowed by the wildcard group")
+		}
+		if !rules.allows("/googlebot-only") {
+			t.Fatalf("expected /googlebot-only to be allowed for an agent outside the GoogleBot/BingBot group")
+		}
+	})
+
+	t.Run("specific group shadows the wildcard group", func(t *testing.T) {
+		rules := parseRobotsTxt(body, "BingBot")
+		if rules.allows("/googlebot-only") {
+			t.Fatalf("expected /googlebot-only to be disallowed for BingBot")
+		}
+		if !rules.allows("/private/secret") {
+			t.Fatalf("the wildcard group's Disallow: /private must not leak into BingBot's group")
+		}
+	})
+}
+
+func TestRobotsRulesAllowsLongestPrefixWins(t *testing.T) {
+	rules := &robotsRules{
+		disallow: []string{"/a"},
+		allow:    []string{"/a/b"},
+	}
+	if !rules.allows("/a/b/c") {
+		t.Fatalf("expected the longer, more specific Allow prefix to win")
+	}
+	if rules.allows("/a/c") {
+		t.Fatalf("expected paths outside the Allow prefix to remain disallowed")
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
@@ -29,6 +30,52 @@ var (
 	cahceClient = cache.New(24*time.Hour, 7*24*time.Hour)
 )
 
+// cacheEntry is what cahceClient stores for a URI: the parsed article plus
+// the HTTP caching state needed to revalidate it once it goes stale.
+type cacheEntry struct {
+	article      readability.Article
+	etag         string
+	lastModified string
+	fetchedAt    time.Time
+	expiresAt    time.Time
+	noStore      bool
+}
+
+// fallbackCacheTTL is the freshness window used when a response carries
+// neither a Cache-Control max-age/s-maxage directive nor an Expires header.
+const fallbackCacheTTL = 24 * time.Hour
+
+// cacheExpiry derives the freshness window from resp's caching headers,
+// honoring Cache-Control's max-age/s-maxage/no-store and falling back to
+// Expires (parsed via http.ParseTime) when Cache-Control is absent.
+func cacheExpiry(resp *http.Response, now time.Time) (expiresAt time.Time, noStore bool) {
+	if cc := resp.Header.Get("Cache-Control"); cc != "" {
+		for _, part := range strings.Split(cc, ",") {
+			name, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+			name = strings.ToLower(strings.TrimSpace(name))
+			switch name {
+			case "no-store":
+				noStore = true
+			case "max-age", "s-maxage":
+				if seconds, err := strconv.Atoi(strings.TrimSpace(value)); err == nil {
+					expiresAt = now.Add(time.Duration(seconds) * time.Second)
+				}
+			}
+		}
+		if expiresAt.IsZero() {
+			expiresAt = now.Add(fallbackCacheTTL)
+		}
+		return expiresAt, noStore
+	}
+
+	if expires := resp.Header.Get("Expires"); expires != "" {
+		if t, err := http.ParseTime(expires); err == nil {
+			return t, false
+		}
+	}
+	return now.Add(fallbackCacheTTL), false
+}
+
 func initSession() {
 	sess = session.New(session.WithClientHelloID(utls.HelloChrome_100_PSK))
 	sess.Timeout = defaultSessionTimeout
@@ -43,29 +90,88 @@ func initBrowser() {
 	browser = rod.New().ControlURL(browserURL).MustConnect()
 }
 
+// Read fetches uri (via plain HTTP or a browser, per isUsingBrowser) and
+// returns its parsed article, honoring HTTP caching semantics rather than a
+// fixed TTL: a still-fresh cached entry is served directly, a stale one is
+// revalidated with a conditional GET (If-None-Match / If-Modified-Since),
+// and a 304 Not Modified bumps the entry's freshness window instead of
+// re-parsing. Browser-driven fetches have no response headers to revalidate
+// against, so a stale entry is refetched in full and falls back to the
+// default freshness window. Responses marked Cache-Control: no-store are never cached.
 func Read(ctx context.Context, uri string, isFormatMarkdown bool, isUsingBrowser bool) (readability.Article, error) {
 	parsedURL, _ := url.ParseRequestURI(uri)
 	var article readability.Article
-	var err error
-	var html string
+	now := time.Now()
 
+	var cached cacheEntry
+	var haveCached bool
 	if cahceClient != nil {
-		article, ok := cahceClient.Get(uri)
-		if ok {
-			return article.(readability.Article), nil
+		if v, ok := cahceClient.Get(uri); ok {
+			cached, haveCached = v.(cacheEntry)
+			if haveCached && now.Before(cached.expiresAt) {
+				return cached.article, nil
+			}
 		}
 	}
 
 	if isUsingBrowser {
-		html, err = readWithBrowser(uri)
-	} else {
-		html, err = read(parsedURL)
+		html, err := readWithBrowser(uri)
+		if err != nil {
+			return article, fmt.Errorf("failed to read url: %w", err)
+		}
+		return parseAndCache(uri, parsedURL, html, isFormatMarkdown, nil, now)
 	}
 
+	var cond condHeaders
+	if haveCached {
+		cond = condHeaders{etag: cached.etag, lastModified: cached.lastModified}
+	}
+	html, resp, err := read(parsedURL, cond)
 	if err != nil {
 		return article, fmt.Errorf("failed to read url: %w", err)
 	}
-	article, err = readability.FromReader(strings.NewReader(html), parsedURL)
+
+	if resp.StatusCode == http.StatusNotModified {
+		if !haveCached {
+			return article, fmt.Errorf("received 304 Not Modified with no cached entry for %s", uri)
+		}
+		expiresAt, noStore := cacheExpiry(resp, now)
+		if resp.Header.Get("Cache-Control") == "" && resp.Header.Get("Expires") == "" {
+			// The 304 carries no caching headers of its own: extend the
+			// entry by the freshness window it originally had rather than
+			// falling back to fallbackCacheTTL.
+			if window := cached.expiresAt.Sub(cached.fetchedAt); window > 0 {
+				expiresAt = now.Add(window)
+			}
+		}
+		if etag := resp.Header.Get("ETag"); etag != "" {
+			cached.etag = etag
+		}
+		if lastModified := resp.Header.Get("Last-Modified"); lastModified != "" {
+			cached.lastModified = lastModified
+		}
+		cached.fetchedAt = now
+		cached.expiresAt = expiresAt
+		cached.noStore = noStore
+		if cahceClient != nil && !noStore {
+			cahceClient.Set(uri, cached, time.Until(expiresAt))
+		}
+		return cached.article, nil
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return article, fmt.Errorf("failed to read url: unexpected status %s", resp.Status)
+	}
+
+	return parseAndCache(uri, parsedURL, html, isFormatMarkdown, resp, now)
+}
+
+// parseAndCache parses html into an article, converts it to Markdown if
+// requested, and stores it under uri together with the caching metadata
+// derived from resp (ETag, Last-Modified, expiry, no-store), unless resp is
+// nil (a browser-driven fetch has no response to derive metadata from, so it
+// falls back to the default freshness window with no validators).
+func parseAndCache(uri string, parsedURL *url.URL, html string, isFormatMarkdown bool, resp *http.Response, now time.Time) (readability.Article, error) {
+	article, err := readability.FromReader(strings.NewReader(html), parsedURL)
 	if err != nil {
 		return article, fmt.Errorf("failed to parse %s, %v\n", uri, err)
 	}
@@ -79,30 +185,57 @@ func Read(ctx context.Context, uri string, isFormatMarkdown bool, isUsingBrowser
 		article.Content = markdown
 	}
 	article.Node = nil
-	cahceClient.SetDefault(uri, article)
+
+	entry := cacheEntry{article: article, fetchedAt: now, expiresAt: now.Add(fallbackCacheTTL)}
+	if resp != nil {
+		entry.etag = resp.Header.Get("ETag")
+		entry.lastModified = resp.Header.Get("Last-Modified")
+		entry.expiresAt, entry.noStore = cacheExpiry(resp, now)
+	}
+	if cahceClient != nil && !entry.noStore {
+		cahceClient.Set(uri, entry, time.Until(entry.expiresAt))
+	}
 	return article, nil
 }
 
-func read(u *url.URL) (string, error) {
+// condHeaders carries the validators used to make a conditional GET request.
+type condHeaders struct {
+	etag         string // sent as If-None-Match when non-empty
+	lastModified string // sent as If-Modified-Since when non-empty
+}
+
+// read issues a GET request for u, attaching If-None-Match / If-Modified-Since
+// when cond carries them, and returns the response body alongside the
+// *http.Response so the caller can inspect its status and caching headers.
+func read(u *url.URL, cond condHeaders) (string, *http.Response, error) {
 	if sess == nil {
 		initSession()
 	}
 
 	uri := u.String()
 
-	req, _ := http.NewRequest("GET", uri, nil)
+	req, err := http.NewRequest("GET", uri, nil)
+	if err != nil {
+		return "", nil, err
+	}
 	req.Header.Set("User-Agent", defaultUserAgent)
+	if cond.etag != "" {
+		req.Header.Set("If-None-Match", cond.etag)
+	}
+	if cond.lastModified != "" {
+		req.Header.Set("If-Modified-Since", cond.lastModified)
+	}
 
-	resp, err := sess.Get(uri)
+	resp, err := sess.Client.Do(req)
 	if err != nil {
-		return "", err
+		return "", nil, err
 	}
 	defer resp.Body.Close()
 	content, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return "", err
+		return "", nil, err
 	}
-	return string(content), err
+	return string(content), resp, nil
 }
 
 func readWithBrowser(uri string) (string, error) {